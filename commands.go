@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/adamjedlicka/lang/lang"
+	"github.com/adamjedlicka/lang/src/code"
+	"github.com/adamjedlicka/lang/src/compiler"
+	"github.com/adamjedlicka/lang/src/config"
+	"github.com/adamjedlicka/lang/src/debug"
+	"github.com/adamjedlicka/lang/src/vm"
+)
+
+func runCommand(args []string) int {
+	fs := newFlagSet("run [flags] file")
+	config.EngineFlags(fs)
+	config.DebugFlags(fs)
+
+	filename, status := parseFlags(fs, args)
+	if filename == "" {
+		return status
+	}
+
+	source, err := loadFile(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitNoInput
+	}
+
+	switch config.FlagEngine {
+	case config.EngineTree:
+		l := lang.MakeLang()
+		l.SetArgs(fs.Args()[1:])
+
+		return exitCode(l.Run(string(source)))
+	case config.EngineVM:
+		chunk := compile(source)
+		if chunk == nil {
+			return exitCompile
+		}
+
+		machine := vm.NewVM()
+		machine.SetArgs(fs.Args()[1:])
+
+		return exitCode(machine.Interpret(chunk))
+	}
+
+	return unknownEngine()
+}
+
+func replCommand(args []string) int {
+	fs := newFlagSet("repl")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return flagError(err)
+	}
+
+	l := lang.MakeLang()
+
+	return exitCode(l.RunPrompt())
+}
+
+func disasmCommand(args []string) int {
+	fs := newFlagSet("disasm file")
+
+	filename, status := parseFlags(fs, args)
+	if filename == "" {
+		return status
+	}
+
+	source, err := loadFile(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitNoInput
+	}
+
+	chunk := compile(source)
+	if chunk == nil {
+		return exitCompile
+	}
+
+	debug.DisassembleChunk(chunk, filename)
+
+	return exitOk
+}
+
+func checkCommand(args []string) int {
+	fs := newFlagSet("check [flags] file")
+	config.EngineFlags(fs)
+
+	filename, status := parseFlags(fs, args)
+	if filename == "" {
+		return status
+	}
+
+	source, err := loadFile(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitNoInput
+	}
+
+	switch config.FlagEngine {
+	case config.EngineTree:
+		l := lang.MakeLang()
+
+		return exitCode(l.Check(string(source)))
+	case config.EngineVM:
+		if compile(source) == nil {
+			return exitCompile
+		}
+
+		return exitOk
+	}
+
+	return unknownEngine()
+}
+
+func versionCommand(args []string) int {
+	fmt.Printf("blu %s\n", config.Version)
+
+	return exitOk
+}
+
+func newFlagSet(usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("blu", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: blu %s\n", usage)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parseFlags parses the flags and returns the name of the file given as the first positional argument.
+// When no file is returned the second return value is the exit code the command should end with.
+func parseFlags(fs *flag.FlagSet, args []string) (string, int) {
+	err := fs.Parse(args)
+	if err != nil {
+		return "", flagError(err)
+	}
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return "", exitUsage
+	}
+
+	return fs.Arg(0), exitOk
+}
+
+func flagError(err error) int {
+	if err == flag.ErrHelp {
+		return exitOk
+	}
+
+	return exitUsage
+}
+
+func unknownEngine() int {
+	fmt.Fprintf(os.Stderr, "Unknown engine '%s'.\n", config.FlagEngine)
+
+	return exitUsage
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return exitOk
+	}
+
+	switch err := err.(type) {
+	case lang.Exiter:
+		return err.Code()
+	case vm.Exiter:
+		return err.Code()
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+
+	switch err.(type) {
+	case lang.ScannerError, lang.ParserError, lang.ResolverError:
+		return exitCompile
+	}
+
+	return exitRuntime
+}
+
+func compile(source []rune) *code.Chunk {
+	scanner := compiler.NewScanner(source)
+	parser := compiler.NewParser(scanner)
+
+	return parser.Parse()
+}
+
+func loadFile(filename string) ([]rune, error) {
+	var data []byte
+	var err error
+
+	if filename == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(filename)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Runes(data), nil
+}
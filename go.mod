@@ -1,3 +1,5 @@
 module github.com/adamjedlicka/lang
 
 go 1.12
+
+require github.com/peterh/liner v1.2.2
@@ -0,0 +1,63 @@
+package lang
+
+import (
+	"testing"
+)
+
+const benchmarkSource = `
+fn fibonacci(n) {
+    if n <= 1 {
+        return n;
+    }
+
+    return fibonacci(n - 2) + fibonacci(n - 1);
+}
+
+fn count(n) {
+    var total = 0;
+
+    for i in 0..n {
+        for j in 0..n {
+            if j < i {
+                continue;
+            }
+
+            if j > i {
+                break;
+            }
+
+            total = total + 1;
+        }
+    }
+
+    return total;
+}
+`
+
+// BenchmarkReturn measures function calls which return through nested blocks
+func BenchmarkReturn(b *testing.B) {
+	benchmarkRun(b, "fibonacci(18);")
+}
+
+// BenchmarkLoopExit measures loops left by break and continue
+func BenchmarkLoopExit(b *testing.B) {
+	benchmarkRun(b, "count(200);")
+}
+
+func benchmarkRun(b *testing.B, source string) {
+	l := MakeLang()
+
+	err := l.Run(benchmarkSource)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		err := l.Run(source)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
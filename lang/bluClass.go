@@ -3,28 +3,43 @@ package lang
 type BluClass struct {
 	name         string
 	superclass   *BluClass
-	declarations map[string]Expr
+	traits       []*BluTrait
+	closure      *Env
+	declarations []VarStmnt
 	methods      map[string]Function
+	getters      map[string]Function
+	setters      map[string]Function
+
+	// Static fields and methods of the class
+	fields map[string]interface{}
 }
 
-func MakeBluClass(name string, superclass *BluClass, declarations map[string]Expr, methods map[string]Function) *BluClass {
+func MakeBluClass(
+	name string, superclass *BluClass, traits []*BluTrait, closure *Env, declarations []VarStmnt,
+	methods, getters, setters map[string]Function,
+) *BluClass {
 	return &BluClass{
 		name:         name,
 		superclass:   superclass,
+		traits:       traits,
+		closure:      closure,
 		declarations: declarations,
 		methods:      methods,
+		getters:      getters,
+		setters:      setters,
+		fields:       make(map[string]interface{}),
 	}
 }
 
 func (c *BluClass) Call(interpreter *Interpreter, arguments []interface{}) (interface{}, error) {
 	instance := MakeBluInstance(c)
 
-	err := c.init(instance, interpreter, arguments)
+	err := c.initFields(instance, interpreter)
 	if err != nil {
 		return nil, err
 	}
 
-	if init, ok := c.methods["init"]; ok {
+	if init, ok := c.initializer(); ok {
 		_, err := init.bind(instance).Call(interpreter, arguments)
 		if err != nil {
 			return nil, err
@@ -35,48 +50,150 @@ func (c *BluClass) Call(interpreter *Interpreter, arguments []interface{}) (inte
 }
 
 func (c *BluClass) findMethod(name Token) (interface{}, error) {
-	if method, ok := c.methods[name.lexeme]; ok {
+	if method, ok := c.method(name.lexeme); ok {
 		return method, nil
 	}
 
-	if c.superclass != nil {
-		return c.superclass.findMethod(name)
+	return nil, NewRuntimeError(name.line, "Undefined property '"+name.lexeme+"'.")
+}
+
+// get returns the static member of the class or of its superclasses
+func (c *BluClass) get(name Token) (interface{}, error) {
+	for class := c; class != nil; class = class.superclass {
+		if value, ok := class.fields[name.lexeme]; ok {
+			return value, nil
+		}
 	}
 
 	return nil, NewRuntimeError(name.line, "Undefined property '"+name.lexeme+"'.")
 }
 
-func (c *BluClass) init(instance *BluInstance, interpreter *Interpreter, arguments []interface{}) error {
+func (c *BluClass) set(name Token, value interface{}) {
+	c.fields[name.lexeme] = value
+}
+
+func (c *BluClass) findGetter(name string) (Function, bool) {
+	for class := c; class != nil; class = class.superclass {
+		if getter, ok := class.getters[name]; ok {
+			return getter, true
+		}
+	}
+
+	return Function{}, false
+}
+
+func (c *BluClass) findSetter(name string) (Function, bool) {
+	for class := c; class != nil; class = class.superclass {
+		if setter, ok := class.setters[name]; ok {
+			return setter, true
+		}
+	}
+
+	return Function{}, false
+}
+
+// methodNames returns names of all methods and accessors including the inherited ones
+func (c *BluClass) methodNames() []string {
+	names := make([]string, 0, len(c.methods))
+	for name := range c.methods {
+		names = append(names, name)
+	}
+
+	for name := range c.getters {
+		names = append(names, name)
+	}
+
+	for name := range c.setters {
+		names = append(names, name)
+	}
+
+	for _, trait := range c.traits {
+		for name := range trait.methods {
+			names = append(names, name)
+		}
+	}
+
 	if c.superclass != nil {
-		err := c.superclass.init(instance, interpreter, arguments)
+		names = append(names, c.superclass.methodNames()...)
+	}
+
+	return names
+}
+
+// staticNames returns names of static members including the inherited ones
+func (c *BluClass) staticNames() []string {
+	names := make([]string, 0, len(c.fields))
+	for class := c; class != nil; class = class.superclass {
+		for name := range class.fields {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// initFields sets fields of the new instance to values of their initializers.
+// Fields of the superclass come first, then fields of the class in order of their declaration.
+// Initializers run before init method and they can use this to refer to the fields above them.
+func (c *BluClass) initFields(instance *BluInstance, interpreter *Interpreter) error {
+	if c.superclass != nil {
+		err := c.superclass.initFields(instance, interpreter)
 		if err != nil {
 			return err
 		}
 	}
 
-	for key, declaration := range c.declarations {
-		if declaration == nil {
-			instance.fields[key] = nil
+	// Initializers are resolved in the scope of the class where this is the instance
+	env := MakeEnv(c.closure)
+	_ = env.Define(Token{lexeme: "this"}, instance)
+
+	for _, declaration := range c.declarations {
+		if declaration.initializer == nil {
+			instance.fields[declaration.name.lexeme] = nil
 			continue
 		}
 
-		value, err := interpreter.evaluate(declaration)
+		value, err := interpreter.evaluateIn(declaration.initializer, env)
 		if err != nil {
 			return err
 		}
 
-		instance.fields[key] = value
+		instance.fields[declaration.name.lexeme] = value
 	}
 
 	return nil
 }
 
-func (c *BluClass) Arity() int {
-	if init, ok := c.methods["init"]; ok {
-		return init.Arity()
+// initializer returns init method of the class which is inherited when the class does not declare its own.
+// Init of the superclass is not called automatically by init of the subclass, it has to call super.init explicitly.
+func (c *BluClass) initializer() (Function, bool) {
+	return c.method("init")
+}
+
+// method returns the method of the class or of the closest superclass declaring it.
+// Methods of the class take precedence over methods of its traits, which take precedence over the superclass.
+func (c *BluClass) method(name string) (Function, bool) {
+	for class := c; class != nil; class = class.superclass {
+		if method, ok := class.methods[name]; ok {
+			return method, true
+		}
+
+		for _, trait := range class.traits {
+			if method, ok := trait.methods[name]; ok {
+				return method, true
+			}
+		}
+	}
+
+	return Function{}, false
+}
+
+func (c *BluClass) Signature() Signature {
+	if init, ok := c.initializer(); ok {
+		return init.Signature()
 	}
 
-	return 0
+	return MakeSignature(make([]string, 0), 0, false)
 }
 
 func (c *BluClass) String() string {
@@ -0,0 +1,120 @@
+package lang
+
+// BluEnum is the enumeration of its variants
+type BluEnum struct {
+	name     string
+	variants []*BluVariant
+}
+
+func MakeBluEnum(name string) *BluEnum {
+	return &BluEnum{
+		name:     name,
+		variants: make([]*BluVariant, 0),
+	}
+}
+
+// get returns the value of the variant without fields or the constructor of the variant with fields
+func (e *BluEnum) get(name Token) (interface{}, error) {
+	for _, variant := range e.variants {
+		if variant.name != name.lexeme {
+			continue
+		}
+
+		if variant.unit != nil {
+			return variant.unit, nil
+		}
+
+		return variant, nil
+	}
+
+	return nil, NewRuntimeError(name.line, "Undefined variant '"+name.lexeme+"' of enum '"+e.name+"'.")
+}
+
+func (e *BluEnum) String() string {
+	return e.name
+}
+
+// BluVariant is a variant of the enum, variants with fields are called to create their values
+type BluVariant struct {
+	enum   *BluEnum
+	name   string
+	fields []string
+
+	// The only value of the variant without fields
+	unit *BluEnumValue
+}
+
+func MakeBluVariant(enum *BluEnum, name string, fields []string) *BluVariant {
+	variant := &BluVariant{
+		enum:   enum,
+		name:   name,
+		fields: fields,
+	}
+
+	if len(fields) == 0 {
+		variant.unit = MakeBluEnumValue(variant, nil)
+	}
+
+	return variant
+}
+
+func (v *BluVariant) Call(interpreter *Interpreter, arguments []interface{}) (interface{}, error) {
+	return MakeBluEnumValue(v, arguments), nil
+}
+
+func (v *BluVariant) Signature() Signature {
+	return MakeSignature(v.fields, len(v.fields), false)
+}
+
+func (v *BluVariant) String() string {
+	return "<variant " + v.enum.name + "." + v.name + ">"
+}
+
+var _ Callable = &BluVariant{}
+
+// BluEnumValue is the value of the enum variant holding values of its fields
+type BluEnumValue struct {
+	variant *BluVariant
+	values  []interface{}
+}
+
+func MakeBluEnumValue(variant *BluVariant, values []interface{}) *BluEnumValue {
+	return &BluEnumValue{
+		variant: variant,
+		values:  values,
+	}
+}
+
+func (v *BluEnumValue) get(name Token) (interface{}, error) {
+	for i, field := range v.variant.fields {
+		if field == name.lexeme {
+			return v.values[i], nil
+		}
+	}
+
+	return nil, NewRuntimeError(name.line, "Undefined field '"+name.lexeme+"' of variant '"+v.variant.name+"'.")
+}
+
+// equals reports whether both values are of the same variant and have equal fields
+func (v *BluEnumValue) equals(other *BluEnumValue, interpreter *Interpreter) bool {
+	if v.variant != other.variant {
+		return false
+	}
+
+	for i := range v.values {
+		if !interpreter.isEqual(v.values[i], other.values[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *BluEnumValue) String() string {
+	name := v.variant.enum.name + "." + v.variant.name
+	if v.variant.unit != nil {
+		return name
+	}
+
+	return name + "(...)"
+}
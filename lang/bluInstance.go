@@ -12,7 +12,12 @@ func MakeBluInstance(class *BluClass) *BluInstance {
 	}
 }
 
-func (i *BluInstance) get(name Token) (interface{}, error) {
+// get returns the value computed by the getter, the field or the bound method of the instance
+func (i *BluInstance) get(interpreter *Interpreter, name Token) (interface{}, error) {
+	if getter, ok := i.class.findGetter(name.lexeme); ok {
+		return getter.bind(i).Call(interpreter, nil)
+	}
+
 	if value, ok := i.fields[name.lexeme]; ok {
 		return value, nil
 	}
@@ -25,8 +30,21 @@ func (i *BluInstance) get(name Token) (interface{}, error) {
 	return (method.(Function)).bind(i), nil
 }
 
-func (i *BluInstance) set(name Token, value interface{}) {
+// set passes the value to the setter of the property or stores it in the field unless the property is read-only
+func (i *BluInstance) set(interpreter *Interpreter, name Token, value interface{}) error {
+	if setter, ok := i.class.findSetter(name.lexeme); ok {
+		_, err := setter.bind(i).Call(interpreter, []interface{}{value})
+		return err
+	}
+
+	// Property with only a getter is read-only, a field under its name would never be read anyway
+	if _, ok := i.class.findGetter(name.lexeme); ok {
+		return NewRuntimeError(name.line, "Cannot assign to read-only property '"+name.lexeme+"'.")
+	}
+
 	i.fields[name.lexeme] = value
+
+	return nil
 }
 
 func (i *BluInstance) String() string {
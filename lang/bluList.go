@@ -0,0 +1,136 @@
+package lang
+
+import (
+	"fmt"
+	"math"
+)
+
+type BluList struct {
+	elements []interface{}
+}
+
+func MakeBluList(elements []interface{}) *BluList {
+	return &BluList{
+		elements: elements,
+	}
+}
+
+func (l *BluList) get(name Token) (interface{}, error) {
+	switch name.lexeme {
+	case "len":
+		return MakeNativeMethod(name.lexeme, 0, func(arguments []interface{}) (interface{}, error) {
+			return float64(len(l.elements)), nil
+		}), nil
+	case "push":
+		return MakeNativeMethod(name.lexeme, 1, func(arguments []interface{}) (interface{}, error) {
+			l.elements = append(l.elements, arguments[0])
+
+			return nil, nil
+		}), nil
+	case "pop":
+		return MakeNativeMethod(name.lexeme, 0, func(arguments []interface{}) (interface{}, error) {
+			if len(l.elements) == 0 {
+				return nil, NewNativeError("Cannot pop from an empty list.")
+			}
+
+			value := l.elements[len(l.elements)-1]
+			l.elements = l.elements[:len(l.elements)-1]
+
+			return value, nil
+		}), nil
+	case "insert":
+		return MakeNativeMethod(name.lexeme, 2, func(arguments []interface{}) (interface{}, error) {
+			// Inserting at the length of the list appends the value
+			at, err := l.index(arguments[0], len(l.elements)+1)
+			if err != nil {
+				return nil, err
+			}
+
+			l.elements = append(l.elements, nil)
+			copy(l.elements[at+1:], l.elements[at:])
+			l.elements[at] = arguments[1]
+
+			return nil, nil
+		}), nil
+	case "remove":
+		return MakeNativeMethod(name.lexeme, 1, func(arguments []interface{}) (interface{}, error) {
+			at, err := l.index(arguments[0], len(l.elements))
+			if err != nil {
+				return nil, err
+			}
+
+			value := l.elements[at]
+			l.elements = append(l.elements[:at], l.elements[at+1:]...)
+
+			return value, nil
+		}), nil
+	case "slice":
+		return MakeVariadicNativeMethod(name.lexeme, 1, 2, func(arguments []interface{}) (interface{}, error) {
+			start, err := l.index(arguments[0], len(l.elements)+1)
+			if err != nil {
+				return nil, err
+			}
+
+			end := len(l.elements)
+			if len(arguments) == 2 {
+				end, err = l.index(arguments[1], len(l.elements)+1)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if start > end {
+				return nil, NewNativeError("Start of the slice cannot be greater than its end.")
+			}
+
+			elements := make([]interface{}, end-start)
+			copy(elements, l.elements[start:end])
+
+			return MakeBluList(elements), nil
+		}), nil
+	}
+
+	return nil, NewRuntimeError(name.line, "Undefined property '"+name.lexeme+"'.")
+}
+
+func (l *BluList) getIndex(index interface{}) (interface{}, error) {
+	at, err := l.index(index, len(l.elements))
+	if err != nil {
+		return nil, err
+	}
+
+	return l.elements[at], nil
+}
+
+func (l *BluList) setIndex(index interface{}, value interface{}) error {
+	at, err := l.index(index, len(l.elements))
+	if err != nil {
+		return err
+	}
+
+	l.elements[at] = value
+
+	return nil
+}
+
+// index checks that the value is a valid index into the range [0, length)
+func (l *BluList) index(index interface{}, length int) (int, error) {
+	number, ok := index.(float64)
+	if !ok || number != math.Trunc(number) {
+		return 0, NewNativeError("List index must be an integer.")
+	}
+
+	if number < 0 {
+		return 0, NewNativeError(fmt.Sprintf("List index %v cannot be negative.", number))
+	}
+
+	if number >= float64(length) {
+		return 0, NewNativeError(fmt.Sprintf("List index %v out of range for length %d.", number, len(l.elements)))
+	}
+
+	return int(number), nil
+}
+
+func (l *BluList) String() string {
+	return "<list>"
+}
@@ -0,0 +1,145 @@
+package lang
+
+import (
+	"math"
+)
+
+// BluMap is a hash map which remembers the insertion order of its keys.
+//
+// Strings, numbers, booleans and null keys are compared by value, instances by identity.
+// Unlike the == operator, the map never calls __eq, so two distinct instances are always different keys
+// even when their class makes them equal. Other values cannot be used as keys.
+type BluMap struct {
+	indexes map[interface{}]int
+	keys    []interface{}
+	values  []interface{}
+}
+
+func MakeBluMap() *BluMap {
+	return &BluMap{
+		indexes: make(map[interface{}]int),
+		keys:    make([]interface{}, 0),
+		values:  make([]interface{}, 0),
+	}
+}
+
+func (m *BluMap) get(name Token) (interface{}, error) {
+	switch name.lexeme {
+	case "len":
+		return MakeNativeMethod(name.lexeme, 0, func(arguments []interface{}) (interface{}, error) {
+			return float64(len(m.keys)), nil
+		}), nil
+	case "has":
+		return MakeNativeMethod(name.lexeme, 1, func(arguments []interface{}) (interface{}, error) {
+			err := m.checkKey(arguments[0])
+			if err != nil {
+				return nil, err
+			}
+
+			_, ok := m.indexes[arguments[0]]
+
+			return ok, nil
+		}), nil
+	case "delete":
+		return MakeNativeMethod(name.lexeme, 1, func(arguments []interface{}) (interface{}, error) {
+			return m.delete(arguments[0])
+		}), nil
+	case "keys":
+		return MakeNativeMethod(name.lexeme, 0, func(arguments []interface{}) (interface{}, error) {
+			keys := make([]interface{}, len(m.keys))
+			copy(keys, m.keys)
+
+			return MakeBluList(keys), nil
+		}), nil
+	case "values":
+		return MakeNativeMethod(name.lexeme, 0, func(arguments []interface{}) (interface{}, error) {
+			values := make([]interface{}, len(m.values))
+			copy(values, m.values)
+
+			return MakeBluList(values), nil
+		}), nil
+	}
+
+	return nil, NewRuntimeError(name.line, "Undefined property '"+name.lexeme+"'.")
+}
+
+// getIndex returns the value stored under the key or null when there is none
+func (m *BluMap) getIndex(key interface{}) (interface{}, error) {
+	err := m.checkKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if index, ok := m.indexes[key]; ok {
+		return m.values[index], nil
+	}
+
+	return nil, nil
+}
+
+func (m *BluMap) setIndex(key interface{}, value interface{}) error {
+	err := m.checkKey(key)
+	if err != nil {
+		return err
+	}
+
+	if index, ok := m.indexes[key]; ok {
+		m.values[index] = value
+		return nil
+	}
+
+	m.indexes[key] = len(m.keys)
+	m.keys = append(m.keys, key)
+	m.values = append(m.values, value)
+
+	return nil
+}
+
+// delete removes the key from the map and returns its value
+func (m *BluMap) delete(key interface{}) (interface{}, error) {
+	err := m.checkKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	index, ok := m.indexes[key]
+	if !ok {
+		return nil, nil
+	}
+
+	value := m.values[index]
+
+	delete(m.indexes, key)
+	m.keys = append(m.keys[:index], m.keys[index+1:]...)
+	m.values = append(m.values[:index], m.values[index+1:]...)
+
+	for i := index; i < len(m.keys); i++ {
+		m.indexes[m.keys[i]] = i
+	}
+
+	return value, nil
+}
+
+func (m *BluMap) checkKey(key interface{}) error {
+	switch key := key.(type) {
+	case nil, bool, string, *BluInstance:
+		return nil
+	case float64:
+		if math.IsNaN(key) {
+			return NewNativeError("Map key cannot be NaN.")
+		}
+
+		return nil
+	case *BluEnumValue:
+		// Values of variants with fields are equal by their contents, not by their identity
+		if key.variant.unit != nil {
+			return nil
+		}
+	}
+
+	return NewNativeError("Map key must be a string, number, boolean, null, instance or enum variant without fields.")
+}
+
+func (m *BluMap) String() string {
+	return "<map>"
+}
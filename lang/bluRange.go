@@ -0,0 +1,22 @@
+package lang
+
+import (
+	"strconv"
+)
+
+// BluRange is a sequence of integers from start up to, but not including, end
+type BluRange struct {
+	start float64
+	end   float64
+}
+
+func MakeBluRange(start, end float64) *BluRange {
+	return &BluRange{
+		start: start,
+		end:   end,
+	}
+}
+
+func (r *BluRange) String() string {
+	return strconv.FormatFloat(r.start, 'f', -1, 64) + ".." + strconv.FormatFloat(r.end, 'f', -1, 64)
+}
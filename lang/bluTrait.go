@@ -0,0 +1,20 @@
+package lang
+
+// BluTrait is a set of methods which can be mixed into classes
+type BluTrait struct {
+	name     string
+	methods  map[string]Function
+	required []Token
+}
+
+func MakeBluTrait(name string, methods map[string]Function, required []Token) *BluTrait {
+	return &BluTrait{
+		name:     name,
+		methods:  methods,
+		required: required,
+	}
+}
+
+func (t *BluTrait) String() string {
+	return t.name
+}
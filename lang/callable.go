@@ -2,6 +2,6 @@ package lang
 
 type Callable interface {
 	Call(interpreter *Interpreter, arguments []interface{}) (interface{}, error)
-	Arity() int
+	Signature() Signature
 	String() string
 }
@@ -0,0 +1,104 @@
+package lang
+
+import (
+	"sort"
+	"strings"
+)
+
+// complete returns completions of the word in front of the cursor, pos is the index of the rune under the cursor
+func (r *Repl) complete(line string, pos int) (string, []string, string) {
+	runes := []rune(line)
+
+	start := pos
+	for start > 0 && isCompletionRune(runes[start-1]) {
+		start--
+	}
+
+	head, word, tail := string(runes[:start]), string(runes[start:pos]), string(runes[pos:])
+
+	var candidates []string
+	var prefix string
+
+	if dot := strings.LastIndex(word, "."); dot >= 0 {
+		prefix = word[:dot+1]
+		candidates = r.properties(word[:dot])
+		word = word[dot+1:]
+	} else {
+		candidates = r.names()
+	}
+
+	completions := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) && !seen[candidate] {
+			seen[candidate] = true
+			completions = append(completions, prefix+candidate)
+		}
+	}
+
+	sort.Strings(completions)
+
+	return head, completions, tail
+}
+
+// names returns keywords and all global variables.
+// Input of the prompt runs in the global scope, local variables exist only while it runs,
+// so globals are all the variables visible when completing.
+func (r *Repl) names() []string {
+	names := make([]string, 0, len(keywords))
+
+	for keyword := range keywords {
+		names = append(names, keyword)
+	}
+
+	return append(names, r.lang.interpreter.globals.names()...)
+}
+
+// properties returns fields and methods of the instance or static members of the class the path like "list.head.next" points to
+func (r *Repl) properties(path string) []string {
+	parts := strings.Split(path, ".")
+
+	value, ok := r.lookUp(parts[0])
+	if !ok {
+		return nil
+	}
+
+	for _, part := range parts[1:] {
+		instance, ok := value.(*BluInstance)
+		if !ok {
+			return nil
+		}
+
+		value, ok = instance.fields[part]
+		if !ok {
+			return nil
+		}
+	}
+
+	if class, ok := value.(*BluClass); ok {
+		return class.staticNames()
+	}
+
+	instance, ok := value.(*BluInstance)
+	if !ok {
+		return nil
+	}
+
+	properties := make([]string, 0, len(instance.fields))
+	for name := range instance.fields {
+		properties = append(properties, name)
+	}
+
+	return append(properties, instance.class.methodNames()...)
+}
+
+func (r *Repl) lookUp(name string) (interface{}, bool) {
+	value, ok := r.lang.interpreter.globals.globals[name]
+
+	return value, ok
+}
+
+func isCompletionRune(r rune) bool {
+	return r == '.' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
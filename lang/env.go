@@ -4,49 +4,49 @@ import (
 	"fmt"
 )
 
+// Env holds variables of a single scope.
+// The global scope stores variables by name, local scopes store them in slots
+// in the order of their declaration which is the index assigned by the resolver.
 type Env struct {
 	enclosing *Env
-	values    map[string]interface{}
+	values    []interface{}
+	globals   map[string]interface{}
 }
 
 func MakeEnv(enclosing *Env) *Env {
 	env := new(Env)
 	env.enclosing = enclosing
-	env.values = make(map[string]interface{})
 
 	return env
 }
 
-func (env *Env) Define(name Token, value interface{}) error {
-	if _, ok := env.values[name.lexeme]; !ok {
-		env.values[name.lexeme] = value
-		return nil
-	}
+// MakeGlobalEnv creates the outermost environment with variables looked up by their names
+func MakeGlobalEnv() *Env {
+	env := new(Env)
+	env.globals = make(map[string]interface{})
 
-	return NewRuntimeError(
-		name.line,
-		fmt.Sprintf("Variable '%s' already defined.", name.lexeme))
+	return env
 }
 
-func (env *Env) Assign(name Token, value interface{}) error {
-	if _, ok := env.values[name.lexeme]; ok {
-		env.values[name.lexeme] = value
-
+func (env *Env) Define(name Token, value interface{}) error {
+	if env.globals == nil {
+		env.values = append(env.values, value)
 		return nil
 	}
 
-	if env.enclosing != nil {
-		return env.enclosing.Assign(name, value)
+	if _, ok := env.globals[name.lexeme]; !ok {
+		env.globals[name.lexeme] = value
+		return nil
 	}
 
 	return NewRuntimeError(
 		name.line,
-		fmt.Sprintf("Cannot assign to undefined variable '%s'.", name.lexeme))
+		fmt.Sprintf("Variable '%s' already defined.", name.lexeme))
 }
 
-func (env *Env) AssignAt(distance int, name Token, value interface{}) error {
-	if _, ok := env.ancestor(distance).values[name.lexeme]; ok {
-		env.ancestor(distance).values[name.lexeme] = value
+func (env *Env) Assign(name Token, value interface{}) error {
+	if _, ok := env.globals[name.lexeme]; ok {
+		env.globals[name.lexeme] = value
 
 		return nil
 	}
@@ -56,28 +56,31 @@ func (env *Env) AssignAt(distance int, name Token, value interface{}) error {
 		fmt.Sprintf("Cannot assign to undefined variable '%s'.", name.lexeme))
 }
 
+func (env *Env) AssignAt(distance, index int, value interface{}) {
+	env.ancestor(distance).values[index] = value
+}
+
 func (env *Env) Get(name Token) (interface{}, error) {
-	if value, ok := env.values[name.lexeme]; ok {
+	if value, ok := env.globals[name.lexeme]; ok {
 		return value, nil
 	}
 
-	if env.enclosing != nil {
-		return env.enclosing.Get(name)
-	}
-
 	return nil, NewRuntimeError(
 		name.line,
 		fmt.Sprintf("Undefined variable '%s'.", name.lexeme))
 }
 
-func (env *Env) GetAt(distance int, name Token) (interface{}, error) {
-	if value, ok := env.ancestor(distance).values[name.lexeme]; ok {
-		return value, nil
+func (env *Env) GetAt(distance, index int) interface{} {
+	return env.ancestor(distance).values[index]
+}
+
+func (env *Env) names() []string {
+	names := make([]string, 0, len(env.globals))
+	for name := range env.globals {
+		names = append(names, name)
 	}
 
-	return nil, NewRuntimeError(
-		name.line,
-		fmt.Sprintf("Undefined variable '%s'.", name.lexeme))
+	return names
 }
 
 func (env *Env) ancestor(distance int) *Env {
@@ -0,0 +1,34 @@
+package lang
+
+import (
+	"math"
+)
+
+type Exit struct {
+}
+
+func (e Exit) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) == 0 {
+		return nil, MakeExiter(0)
+	}
+
+	code, ok := arguments[0].(float64)
+	if !ok || code != math.Trunc(code) {
+		return nil, NewNativeError("Exit code must be an integer.")
+	}
+
+	// Operating systems keep only the lowest byte of the status
+	if code < 0 || code > 255 {
+		return nil, NewNativeError("Exit code must be between 0 and 255.")
+	}
+
+	return nil, MakeExiter(int(code))
+}
+
+func (e Exit) Signature() Signature {
+	return MakeNativeSignature(0, 1)
+}
+
+func (e Exit) String() string {
+	return "<native fn>"
+}
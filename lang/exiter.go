@@ -0,0 +1,25 @@
+package lang
+
+import (
+	"fmt"
+)
+
+// Exiter unwinds the execution of the script when the exit function is called
+type Exiter struct {
+	code int
+}
+
+func MakeExiter(code int) Exiter {
+	return Exiter{
+		code: code,
+	}
+}
+
+// Code returns the exit code requested by the script
+func (e Exiter) Code() int {
+	return e.code
+}
+
+func (e Exiter) Error() string {
+	return fmt.Sprintf("EXIT: %v", e.code)
+}
@@ -8,11 +8,18 @@ type ExprVisitor interface {
 	VisitAssignExpr(AssignExpr) (interface{}, error)
 	VisitBinaryExpr(BinaryExpr) (interface{}, error)
 	VisitCallExpr(CallExpr) (interface{}, error)
+	VisitConditionalExpr(ConditionalExpr) (interface{}, error)
 	VisitGetExpr(GetExpr) (interface{}, error)
 	VisitGroupingExpr(GroupingExpr) (interface{}, error)
+	VisitIndexGetExpr(IndexGetExpr) (interface{}, error)
+	VisitIndexSetExpr(IndexSetExpr) (interface{}, error)
 	VisitLambdaExpr(LambdaExpr) (interface{}, error)
+	VisitListExpr(ListExpr) (interface{}, error)
 	VisitLiteralExpr(LiteralExpr) (interface{}, error)
 	VisitLogicalExpr(LogicalExpr) (interface{}, error)
+	VisitMapExpr(MapExpr) (interface{}, error)
+	VisitMatchExpr(MatchExpr) (interface{}, error)
+	VisitOptionalChainExpr(OptionalChainExpr) (interface{}, error)
 	VisitSetExpr(SetExpr) (interface{}, error)
 	VisitSuperExpr(SuperExpr) (interface{}, error)
 	VisitThisExpr(ThisExpr) (interface{}, error)
@@ -20,15 +27,23 @@ type ExprVisitor interface {
 	VisitVariableExpr(VariableExpr) (interface{}, error)
 }
 
+// AssignExpr assigns the value to the variable, operator is the binary operator of the compound assignment or nil
+// and postfix makes the expression evaluate to the previous value like x++ does
 type AssignExpr struct {
-	name  Token
-	value Expr
+	id       int
+	name     Token
+	operator *Token
+	value    Expr
+	postfix  bool
 }
 
-func MakeAssignExpr(name Token, value Expr) AssignExpr {
+func MakeAssignExpr(name Token, operator *Token, value Expr, postfix bool) AssignExpr {
 	return AssignExpr{
-		name:  name,
-		value: value,
+		id:       nextNodeID(),
+		name:     name,
+		operator: operator,
+		value:    value,
+		postfix:  postfix,
 	}
 }
 
@@ -54,17 +69,20 @@ func (e BinaryExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 	return visitor.VisitBinaryExpr(e)
 }
 
+// CallExpr calls the callee, the last len(names) arguments are passed by the names
 type CallExpr struct {
 	callee    Expr
 	paren     Token
 	arguments []Expr
+	names     []Token
 }
 
-func MakeCallExpr(callee Expr, paren Token, arguments []Expr) CallExpr {
+func MakeCallExpr(callee Expr, paren Token, arguments []Expr, names []Token) CallExpr {
 	return CallExpr{
 		callee:    callee,
 		paren:     paren,
 		arguments: arguments,
+		names:     names,
 	}
 }
 
@@ -72,15 +90,38 @@ func (e CallExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 	return visitor.VisitCallExpr(e)
 }
 
+type ConditionalExpr struct {
+	condition  Expr
+	question   Token
+	thenBranch Expr
+	elseBranch Expr
+}
+
+func MakeConditionalExpr(condition Expr, question Token, thenBranch, elseBranch Expr) ConditionalExpr {
+	return ConditionalExpr{
+		condition:  condition,
+		question:   question,
+		thenBranch: thenBranch,
+		elseBranch: elseBranch,
+	}
+}
+
+func (e ConditionalExpr) Accept(visitor ExprVisitor) (interface{}, error) {
+	return visitor.VisitConditionalExpr(e)
+}
+
+// GetExpr gets the property, optional get "object?.name" short-circuits its chain when the object is null
 type GetExpr struct {
-	object Expr
-	name   Token
+	object   Expr
+	name     Token
+	optional bool
 }
 
-func MakeGetExpr(object Expr, name Token) GetExpr {
+func MakeGetExpr(object Expr, name Token, optional bool) GetExpr {
 	return GetExpr{
-		object: object,
-		name:   name,
+		object:   object,
+		name:     name,
+		optional: optional,
 	}
 }
 
@@ -102,15 +143,63 @@ func (e GroupingExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 	return visitor.VisitGroupingExpr(e)
 }
 
+type IndexGetExpr struct {
+	object  Expr
+	bracket Token
+	index   Expr
+}
+
+func MakeIndexGetExpr(object Expr, bracket Token, index Expr) IndexGetExpr {
+	return IndexGetExpr{
+		object:  object,
+		bracket: bracket,
+		index:   index,
+	}
+}
+
+func (e IndexGetExpr) Accept(visitor ExprVisitor) (interface{}, error) {
+	return visitor.VisitIndexGetExpr(e)
+}
+
+// IndexSetExpr stores the value under the index, operator is the binary operator of the compound assignment or nil
+// and postfix makes the expression evaluate to the previous value like x[i]++ does
+type IndexSetExpr struct {
+	object   Expr
+	bracket  Token
+	index    Expr
+	operator *Token
+	value    Expr
+	postfix  bool
+}
+
+func MakeIndexSetExpr(object Expr, bracket Token, index Expr, operator *Token, value Expr, postfix bool) IndexSetExpr {
+	return IndexSetExpr{
+		object:   object,
+		bracket:  bracket,
+		index:    index,
+		operator: operator,
+		value:    value,
+		postfix:  postfix,
+	}
+}
+
+func (e IndexSetExpr) Accept(visitor ExprVisitor) (interface{}, error) {
+	return visitor.VisitIndexSetExpr(e)
+}
+
 type LambdaExpr struct {
-	params []Token
-	body   []Stmnt
+	params    []Parameter
+	rest      *Token
+	body      []Stmnt
+	signature Signature
 }
 
-func MakeLambdaExpr(params []Token, body []Stmnt) LambdaExpr {
+func MakeLambdaExpr(params []Parameter, rest *Token, body []Stmnt) LambdaExpr {
 	return LambdaExpr{
-		params: params,
-		body:   body,
+		params:    params,
+		rest:      rest,
+		body:      body,
+		signature: makeParameterSignature(params, rest),
 	}
 }
 
@@ -118,6 +207,22 @@ func (e LambdaExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 	return visitor.VisitLambdaExpr(e)
 }
 
+type ListExpr struct {
+	bracket  Token
+	elements []Expr
+}
+
+func MakeListExpr(bracket Token, elements []Expr) ListExpr {
+	return ListExpr{
+		bracket:  bracket,
+		elements: elements,
+	}
+}
+
+func (e ListExpr) Accept(visitor ExprVisitor) (interface{}, error) {
+	return visitor.VisitListExpr(e)
+}
+
 type LiteralExpr struct {
 	value interface{}
 }
@@ -150,17 +255,90 @@ func (e LogicalExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 	return visitor.VisitLogicalExpr(e)
 }
 
+type MapExpr struct {
+	brace  Token
+	keys   []Expr
+	values []Expr
+}
+
+func MakeMapExpr(brace Token, keys []Expr, values []Expr) MapExpr {
+	return MapExpr{
+		brace:  brace,
+		keys:   keys,
+		values: values,
+	}
+}
+
+func (e MapExpr) Accept(visitor ExprVisitor) (interface{}, error) {
+	return visitor.VisitMapExpr(e)
+}
+
+type MatchExpr struct {
+	keyword Token
+	subject Expr
+	arms    []MatchArm
+}
+
+func MakeMatchExpr(keyword Token, subject Expr, arms []MatchArm) MatchExpr {
+	return MatchExpr{
+		keyword: keyword,
+		subject: subject,
+		arms:    arms,
+	}
+}
+
+func (e MatchExpr) Accept(visitor ExprVisitor) (interface{}, error) {
+	return visitor.VisitMatchExpr(e)
+}
+
+// MatchArm is one "pattern if guard => body" arm of the match expression, guard is nil when missing
+type MatchArm struct {
+	pattern Pattern
+	guard   Expr
+	body    Expr
+}
+
+func MakeMatchArm(pattern Pattern, guard Expr, body Expr) MatchArm {
+	return MatchArm{
+		pattern: pattern,
+		guard:   guard,
+		body:    body,
+	}
+}
+
+// OptionalChainExpr evaluates the chain of calls, gets and indexes containing the optional get,
+// the chain evaluates to null when the optional get short-circuits it
+type OptionalChainExpr struct {
+	expr Expr
+}
+
+func MakeOptionalChainExpr(expr Expr) OptionalChainExpr {
+	return OptionalChainExpr{
+		expr: expr,
+	}
+}
+
+func (e OptionalChainExpr) Accept(visitor ExprVisitor) (interface{}, error) {
+	return visitor.VisitOptionalChainExpr(e)
+}
+
+// SetExpr sets the property, operator is the binary operator of the compound assignment or nil
+// and postfix makes the expression evaluate to the previous value like x.y++ does
 type SetExpr struct {
-	object Expr
-	name   Token
-	value  Expr
+	object   Expr
+	name     Token
+	operator *Token
+	value    Expr
+	postfix  bool
 }
 
-func MakeSetExpr(object Expr, name Token, value Expr) SetExpr {
+func MakeSetExpr(object Expr, name Token, operator *Token, value Expr, postfix bool) SetExpr {
 	return SetExpr{
-		object: object,
-		name:   name,
-		value:  value,
+		object:   object,
+		name:     name,
+		operator: operator,
+		value:    value,
+		postfix:  postfix,
 	}
 }
 
@@ -169,12 +347,14 @@ func (e SetExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 }
 
 type SuperExpr struct {
+	id     int
 	keword Token
 	method Token
 }
 
 func MakeSuperExpr(keyword, method Token) SuperExpr {
 	return SuperExpr{
+		id:     nextNodeID(),
 		keword: keyword,
 		method: method,
 	}
@@ -185,11 +365,13 @@ func (e SuperExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 }
 
 type ThisExpr struct {
+	id     int
 	keword Token
 }
 
 func MakeThisExpr(keyword Token) ThisExpr {
 	return ThisExpr{
+		id:     nextNodeID(),
 		keword: keyword,
 	}
 }
@@ -215,11 +397,13 @@ func (e UnaryExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 }
 
 type VariableExpr struct {
+	id   int
 	name Token
 }
 
 func MakeVariableExpr(name Token) VariableExpr {
 	return VariableExpr{
+		id:   nextNodeID(),
 		name: name,
 	}
 }
@@ -227,3 +411,136 @@ func MakeVariableExpr(name Token) VariableExpr {
 func (e VariableExpr) Accept(visitor ExprVisitor) (interface{}, error) {
 	return visitor.VisitVariableExpr(e)
 }
+
+// Pattern is the left-hand side of the match arm which the subject is tested against
+type Pattern interface {
+	isPattern()
+}
+
+// WildcardPattern "_" matches any value without binding it
+type WildcardPattern struct {
+	token Token
+}
+
+func MakeWildcardPattern(token Token) WildcardPattern {
+	return WildcardPattern{
+		token: token,
+	}
+}
+
+func (p WildcardPattern) isPattern() {}
+
+// LiteralPattern matches values equal to the literal
+type LiteralPattern struct {
+	token Token
+	value interface{}
+}
+
+func MakeLiteralPattern(token Token, value interface{}) LiteralPattern {
+	return LiteralPattern{
+		token: token,
+		value: value,
+	}
+}
+
+func (p LiteralPattern) isPattern() {}
+
+// BindingPattern matches any value and binds it to the new variable
+type BindingPattern struct {
+	name Token
+}
+
+func MakeBindingPattern(name Token) BindingPattern {
+	return BindingPattern{
+		name: name,
+	}
+}
+
+func (p BindingPattern) isPattern() {}
+
+// ListPattern matches lists element by element, the rest of the list is bound to rest when hasRest is set
+type ListPattern struct {
+	bracket  Token
+	elements []Pattern
+	hasRest  bool
+	rest     *Token
+}
+
+func MakeListPattern(bracket Token, elements []Pattern, hasRest bool, rest *Token) ListPattern {
+	return ListPattern{
+		bracket:  bracket,
+		elements: elements,
+		hasRest:  hasRest,
+		rest:     rest,
+	}
+}
+
+func (p ListPattern) isPattern() {}
+
+// MapPattern matches maps containing all of the keys whose values match the value patterns
+type MapPattern struct {
+	brace  Token
+	keys   []interface{}
+	values []Pattern
+}
+
+func MakeMapPattern(brace Token, keys []interface{}, values []Pattern) MapPattern {
+	return MapPattern{
+		brace:  brace,
+		keys:   keys,
+		values: values,
+	}
+}
+
+func (p MapPattern) isPattern() {}
+
+// ObjectPattern "{ x, y: 0 }" matches maps by their string keys and instances by their fields
+type ObjectPattern struct {
+	brace  Token
+	names  []Token
+	fields []Pattern
+}
+
+func MakeObjectPattern(brace Token, names []Token, fields []Pattern) ObjectPattern {
+	return ObjectPattern{
+		brace:  brace,
+		names:  names,
+		fields: fields,
+	}
+}
+
+func (p ObjectPattern) isPattern() {}
+
+// ClassPattern "Point { x, y: 0 }" matches instances of the class whose fields match the field patterns
+type ClassPattern struct {
+	class  VariableExpr
+	names  []Token
+	fields []Pattern
+}
+
+func MakeClassPattern(class VariableExpr, names []Token, fields []Pattern) ClassPattern {
+	return ClassPattern{
+		class:  class,
+		names:  names,
+		fields: fields,
+	}
+}
+
+func (p ClassPattern) isPattern() {}
+
+// VariantPattern "Shape.Circle(r)" matches values of the enum variant, fields is nil when the parentheses are missing
+type VariantPattern struct {
+	enum    VariableExpr
+	variant Token
+	fields  []Pattern
+}
+
+func MakeVariantPattern(enum VariableExpr, variant Token, fields []Pattern) VariantPattern {
+	return VariantPattern{
+		enum:    enum,
+		variant: variant,
+		fields:  fields,
+	}
+}
+
+func (p VariantPattern) isPattern() {}
@@ -0,0 +1,13 @@
+package lang
+
+// flow describes how the last executed statement completed.
+// Statements which transfer control set it on the interpreter instead of returning an error,
+// enclosing blocks stop executing once it is not flowNormal and the target of the jump resets it.
+type flow int
+
+const (
+	flowNormal flow = iota
+	flowReturn
+	flowBreak
+	flowContinue
+)
@@ -17,59 +17,33 @@ func MakeFunction(declaration FnStmnt, closure *Env, isInit bool) Function {
 func (f Function) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	env := MakeEnv(f.closure)
 
-	for i, argument := range arguments {
-		err := env.Define(f.declaration.params[i], argument)
-		if err != nil {
-			return nil, err
-		}
+	err := i.defineArguments(env, f.declaration.params, f.declaration.rest, arguments)
+	if err != nil {
+		return nil, err
 	}
 
-	// Automatic calls of super initializers. Not good code, dunno if I should leave it here...
-	// if f.isInit {
-	// 	super, err := env.Get(Token{lexeme: "super"})
-	// 	if err == nil {
-	// 		if init, ok := (super.(*BluClass)).methods["init"]; ok {
-	// 			if init.Arity() == 0 {
-	// 				this, err := env.Get(Token{lexeme: "this"})
-	// 				if err != nil {
-	// 					return nil, err
-	// 				}
-
-	// 				_, err = init.bind(this.(*BluInstance)).Call(i, []interface{}{})
-	// 				if err != nil {
-	// 					return nil, err
-	// 				}
-	// 			}
-	// 		}
-	// 	}
-	// }
-
-	err := i.executeBlock(f.declaration.body, env)
-	if returner, ok := err.(Returner); ok {
-		if f.isInit {
-			return f.closure.GetAt(0, Token{lexeme: "this"})
-		}
-
-		return returner.value, nil
-	} else if err != nil {
+	err = i.executeBlock(f.declaration.body, env)
+	if err != nil {
 		return nil, err
 	}
 
+	value := i.returned()
+
 	if f.isInit {
-		return f.closure.GetAt(0, Token{lexeme: "this"})
+		return f.closure.GetAt(0, 0), nil
 	}
 
-	return nil, nil
+	return value, nil
 }
 
-func (f Function) Arity() int {
-	return len(f.declaration.params)
+func (f Function) Signature() Signature {
+	return f.declaration.signature
 }
 
-func (f Function) bind(instance *BluInstance) Function {
+// bind creates the method with this referring to the instance, or to the class for static methods
+func (f Function) bind(this interface{}) Function {
 	env := MakeEnv(f.closure)
-	token := MakeToken(This, "this", nil, -1, -1, -1)
-	_ = env.Define(token, instance)
+	_ = env.Define(Token{lexeme: "this"}, this)
 
 	return MakeFunction(f.declaration, env, f.isInit)
 }
@@ -77,3 +51,30 @@ func (f Function) bind(instance *BluInstance) Function {
 func (f Function) String() string {
 	return "<fn " + f.declaration.name.lexeme + ">"
 }
+
+// defineArguments defines the parameters in the env of the call, arguments must be arranged by the signature.
+// Default value of the omitted argument is evaluated in the env, so it can refer to the preceding parameters.
+func (i *Interpreter) defineArguments(env *Env, params []Parameter, rest *Token, arguments []interface{}) error {
+	for j, param := range params {
+		argument := arguments[j]
+
+		if argument == missing {
+			var err error
+			argument, err = i.evaluateIn(param.value, env)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := env.Define(param.name, argument)
+		if err != nil {
+			return err
+		}
+	}
+
+	if rest != nil {
+		return env.Define(*rest, arguments[len(params)])
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package lang
+
+import (
+	"os"
+)
+
+type Getenv struct {
+}
+
+func (g Getenv) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	name, ok := arguments[0].(string)
+	if !ok {
+		return nil, NewNativeError("Name of the environment variable must be a string.")
+	}
+
+	if value, ok := os.LookupEnv(name); ok {
+		return value, nil
+	}
+
+	if len(arguments) == 2 {
+		return arguments[1], nil
+	}
+
+	return nil, nil
+}
+
+func (g Getenv) Signature() Signature {
+	return MakeNativeSignature(1, 2)
+}
+
+func (g Getenv) String() string {
+	return "<native fn>"
+}
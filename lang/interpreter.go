@@ -2,36 +2,73 @@ package lang
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 )
 
+// local is the position of the local variable resolved for the node
+type local struct {
+	depth int
+	index int
+}
+
 type Interpreter struct {
 	globals *Env
 	env     *Env
 	stmnts  []Stmnt
-	locals  map[Expr]int
+	locals  map[int]local
+
+	errorClass *BluClass
+
+	// Completion of the last statement, with the returned value or the label of the targeted loop
+	flow        flow
+	returnValue interface{}
+	flowLabel   string
+
+	// Lists and maps being stringified, so a collection containing itself is printed only once
+	stringifying map[interface{}]bool
 }
 
 func MakeInterpreter() Interpreter {
-	env := MakeEnv(nil)
+	env := MakeGlobalEnv()
+
+	env.globals["time"] = Time{}
+	env.globals["env"] = Getenv{}
+	env.globals["exit"] = Exit{}
+	env.globals["args"] = MakeBluList(make([]interface{}, 0))
 
-	env.values["time"] = Time{}
+	for _, native := range reflectionNatives() {
+		env.globals[native.name] = native
+	}
 
 	return Interpreter{
 		globals: env,
 		env:     env,
 		stmnts:  make([]Stmnt, 0),
-		locals:  make(map[Expr]int),
+		locals:  make(map[int]local),
+
+		stringifying: make(map[interface{}]bool),
 	}
 }
 
+// SetArgs exposes command-line arguments to the script as the global args list
+func (i *Interpreter) SetArgs(args []string) {
+	elements := make([]interface{}, len(args))
+	for j, arg := range args {
+		elements[j] = arg
+	}
+
+	i.globals.globals["args"] = MakeBluList(elements)
+}
+
 func (i *Interpreter) Interpret(stmnts []Stmnt) error {
 	i.stmnts = stmnts
 
 	for _, stmnt := range i.stmnts {
 		err := stmnt.Accept(i)
 		if err != nil {
-			return err
+			return i.uncaught(err)
 		}
 	}
 
@@ -57,23 +94,39 @@ func (i *Interpreter) VisitBinaryExpr(expr BinaryExpr) (interface{}, error) {
 		return nil, err
 	}
 
-	switch expr.operator.tokenType {
+	return i.binary(expr.operator, left, right)
+}
+
+// binary applies the binary operator to the evaluated operands
+func (i *Interpreter) binary(operator Token, left, right interface{}) (interface{}, error) {
+	if value, ok, err := i.binaryOperator(operator, left, right); ok || err != nil {
+		return value, err
+	}
+
+	switch operator.tokenType {
+	case Is:
+		switch right.(type) {
+		case *BluClass, *BluTrait, *BluEnum:
+			return isInstance(left, right), nil
+		}
+
+		return nil, NewRuntimeError(operator.line, "Right operand of 'is' must be a class, a trait or an enum.")
 	case Minus:
-		err := i.checkNumberOperands(expr.operator, left, right)
+		err := i.checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 
 		return left.(float64) - right.(float64), nil
 	case Slash:
-		err := i.checkNumberOperands(expr.operator, left, right)
+		err := i.checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 
 		return left.(float64) / right.(float64), nil
 	case Star:
-		err := i.checkNumberOperands(expr.operator, left, right)
+		err := i.checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
@@ -91,45 +144,85 @@ func (i *Interpreter) VisitBinaryExpr(expr BinaryExpr) (interface{}, error) {
 				return left + right, nil
 			}
 
-			return fmt.Sprintf("%s%v", left, right), nil
+			right, err := i.stringify(right)
+			if err != nil {
+				return nil, err
+			}
+
+			return left + right, nil
+		}
+
+		return nil, NewRuntimeError(operator.line, "Operands must be two numbers or two strings.")
+	case Percent, TildeSlash:
+		err := i.checkNumberOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+
+		if right.(float64) == 0 {
+			return nil, NewRuntimeError(operator.line, "Cannot divide by zero.")
 		}
 
-		return nil, NewRuntimeError(expr.operator.line, "Operands must be two numbers or two strings.")
+		if operator.tokenType == Percent {
+			return math.Mod(left.(float64), right.(float64)), nil
+		}
+
+		return math.Trunc(left.(float64) / right.(float64)), nil
+	case StarStar:
+		err := i.checkNumberOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+
+		return math.Pow(left.(float64), right.(float64)), nil
+	case Ampersand, Pipe, Caret, LessLess, GreaterGreater:
+		return i.bitwise(operator, left, right)
 	case Greater:
-		err := i.checkNumberOperands(expr.operator, left, right)
+		err := i.checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 
 		return left.(float64) > right.(float64), nil
 	case GreaterEqual:
-		err := i.checkNumberOperands(expr.operator, left, right)
+		err := i.checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 
 		return left.(float64) >= right.(float64), nil
 	case Less:
-		err := i.checkNumberOperands(expr.operator, left, right)
+		err := i.checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 
 		return left.(float64) < right.(float64), nil
 	case LessEqual:
-		err := i.checkNumberOperands(expr.operator, left, right)
+		err := i.checkNumberOperands(operator, left, right)
 		if err != nil {
 			return nil, err
 		}
 
 		return left.(float64) <= right.(float64), nil
+	case DotDot:
+		err := i.checkNumberOperands(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+
+		if left.(float64) != math.Trunc(left.(float64)) || right.(float64) != math.Trunc(right.(float64)) {
+			return nil, NewRuntimeError(operator.line, "Bounds of the range must be integers.")
+		}
+
+		return MakeBluRange(left.(float64), right.(float64)), nil
 	case BangEqual:
 		return !i.isEqual(left, right), nil
 	case EqualEqual:
 		return i.isEqual(left, right), nil
 	}
 
-	return nil, NewRuntimeError(expr.operator.line, "Error while evaluating binary operand.")
+	return nil, NewRuntimeError(operator.line, "Error while evaluating binary operand.")
 }
 
 func (i *Interpreter) VisitCallExpr(expr CallExpr) (interface{}, error) {
@@ -153,12 +246,27 @@ func (i *Interpreter) VisitCallExpr(expr CallExpr) (interface{}, error) {
 		return nil, NewRuntimeError(expr.paren.line, "Can only call functions and classes.")
 	}
 
-	if function.Arity() != len(arguments) {
-		return nil, NewRuntimeError(expr.paren.line,
-			fmt.Sprintf("Expected %d arguments but got %d.", function.Arity(), len(arguments)))
+	arguments, err = function.Signature().bind(arguments, expr.names)
+	if err != nil {
+		return nil, i.nativeError(err, expr.paren)
+	}
+
+	value, err := function.Call(i, arguments)
+
+	return value, i.addFrame(i.nativeError(err, expr.paren), function, expr.paren)
+}
+
+func (i *Interpreter) VisitConditionalExpr(expr ConditionalExpr) (interface{}, error) {
+	condition, err := i.evaluate(expr.condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.isTruthy(condition) {
+		return i.evaluate(expr.thenBranch)
 	}
 
-	return function.Call(i, arguments)
+	return i.evaluate(expr.elseBranch)
 }
 
 func (i *Interpreter) VisitGetExpr(expr GetExpr) (interface{}, error) {
@@ -167,11 +275,111 @@ func (i *Interpreter) VisitGetExpr(expr GetExpr) (interface{}, error) {
 		return nil, err
 	}
 
-	if object, ok := object.(*BluInstance); ok {
-		return object.get(expr.name)
+	if expr.optional && object == nil {
+		return nil, shortCircuit{}
 	}
 
-	return nil, NewRuntimeError(expr.name.line, "Only instances have properties.")
+	return i.getProperty(object, expr.name)
+}
+
+func (i *Interpreter) getProperty(object interface{}, name Token) (interface{}, error) {
+	switch object := object.(type) {
+	case *BluInstance:
+		return object.get(i, name)
+	case *BluClass:
+		return object.get(name)
+	case *BluEnum:
+		return object.get(name)
+	case *BluEnumValue:
+		return object.get(name)
+	case *BluList:
+		return object.get(name)
+	case *BluMap:
+		return object.get(name)
+	}
+
+	return nil, NewRuntimeError(name.line, "Only instances have properties.")
+}
+
+func (i *Interpreter) VisitIndexGetExpr(expr IndexGetExpr) (interface{}, error) {
+	object, err := i.evaluate(expr.object)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := i.evaluate(expr.index)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.getIndex(object, index, expr.bracket)
+}
+
+func (i *Interpreter) getIndex(object, index interface{}, bracket Token) (interface{}, error) {
+	switch object := object.(type) {
+	case *BluList:
+		value, err := object.getIndex(index)
+
+		return value, i.nativeError(err, bracket)
+	case *BluMap:
+		value, err := object.getIndex(index)
+
+		return value, i.nativeError(err, bracket)
+	}
+
+	if value, ok, err := i.callSpecial(object, "__index", bracket, index); ok || err != nil {
+		return value, err
+	}
+
+	return nil, NewRuntimeError(bracket.line, "Only lists and maps can be indexed.")
+}
+
+func (i *Interpreter) VisitIndexSetExpr(expr IndexSetExpr) (interface{}, error) {
+	object, err := i.evaluate(expr.object)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := i.evaluate(expr.index)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := i.evaluate(expr.value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := value
+	if expr.operator != nil {
+		current, err := i.getIndex(object, index, expr.bracket)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = i.binary(*expr.operator, current, value)
+		if err != nil {
+			return nil, err
+		}
+
+		result = value
+		if expr.postfix {
+			result = current
+		}
+	}
+
+	switch object := object.(type) {
+	case *BluList:
+		return result, i.nativeError(object.setIndex(index, value), expr.bracket)
+	case *BluMap:
+		return result, i.nativeError(object.setIndex(index, value), expr.bracket)
+	}
+
+	if _, ok, err := i.callSpecial(object, "__setindex", expr.bracket, index, value); ok || err != nil {
+		return result, err
+	}
+
+	return nil, NewRuntimeError(expr.bracket.line, "Only lists and maps can be indexed.")
 }
 
 func (i *Interpreter) VisitLogicalExpr(expr LogicalExpr) (interface{}, error) {
@@ -180,7 +388,11 @@ func (i *Interpreter) VisitLogicalExpr(expr LogicalExpr) (interface{}, error) {
 		return nil, err
 	}
 
-	if expr.operator.tokenType == Or {
+	if expr.operator.tokenType == QuestionQuestion {
+		if left != nil {
+			return left, nil
+		}
+	} else if expr.operator.tokenType == Or {
 		if i.isTruthy(left) {
 			return left, nil
 		}
@@ -193,39 +405,117 @@ func (i *Interpreter) VisitLogicalExpr(expr LogicalExpr) (interface{}, error) {
 	return i.evaluate(expr.right)
 }
 
-func (i *Interpreter) VisitSetExpr(expr SetExpr) (interface{}, error) {
-	object, err := i.evaluate(expr.object)
+func (i *Interpreter) VisitMapExpr(expr MapExpr) (interface{}, error) {
+	dictionary := MakeBluMap()
+
+	for j := range expr.keys {
+		key, err := i.evaluate(expr.keys[j])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := i.evaluate(expr.values[j])
+		if err != nil {
+			return nil, err
+		}
+
+		err = dictionary.setIndex(key, value)
+		if err != nil {
+			return nil, i.nativeError(err, expr.brace)
+		}
+	}
+
+	return dictionary, nil
+}
+
+func (i *Interpreter) VisitMatchExpr(expr MatchExpr) (interface{}, error) {
+	subject, err := i.evaluate(expr.subject)
 	if err != nil {
 		return nil, err
 	}
 
-	instance, ok := object.(*BluInstance)
-	if !ok {
-		return nil, NewRuntimeError(expr.name.line, "Only instances have fields.")
+	for _, arm := range expr.arms {
+		value, ok, err := i.matchArm(arm, subject)
+		if ok || err != nil {
+			return value, err
+		}
 	}
 
-	value, err := i.evaluate(expr.value)
+	value, err := i.stringify(subject)
 	if err != nil {
 		return nil, err
 	}
 
-	instance.set(expr.name, value)
+	return nil, NewRuntimeError(expr.keyword.line, "No match arm matches the value "+value+".")
+}
+
+// shortCircuit unwinds the optional chain from the optional get of the null object
+type shortCircuit struct{}
 
-	return value, nil
+func (shortCircuit) Error() string {
+	return "SHORT CIRCUIT"
 }
 
-func (i *Interpreter) VisitSuperExpr(expr SuperExpr) (interface{}, error) {
-	distance := i.locals[expr]
-	superclass, err := i.env.GetAt(distance, Token{lexeme: "super"})
+func (i *Interpreter) VisitOptionalChainExpr(expr OptionalChainExpr) (interface{}, error) {
+	value, err := i.evaluate(expr.expr)
+	if _, ok := err.(shortCircuit); ok {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+func (i *Interpreter) VisitSetExpr(expr SetExpr) (interface{}, error) {
+	object, err := i.evaluate(expr.object)
 	if err != nil {
 		return nil, err
 	}
 
-	instance, err := i.env.GetAt(distance-1, Token{lexeme: "this"})
+	value, err := i.evaluate(expr.value)
 	if err != nil {
 		return nil, err
 	}
 
+	result := value
+	if expr.operator != nil {
+		current, err := i.getProperty(object, expr.name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = i.binary(*expr.operator, current, value)
+		if err != nil {
+			return nil, err
+		}
+
+		result = value
+		if expr.postfix {
+			result = current
+		}
+	}
+
+	switch object := object.(type) {
+	case *BluInstance:
+		return result, object.set(i, expr.name, value)
+	case *BluClass:
+		object.set(expr.name, value)
+		return result, nil
+	}
+
+	return nil, NewRuntimeError(expr.name.line, "Only instances and classes have fields.")
+}
+
+func (i *Interpreter) VisitSuperExpr(expr SuperExpr) (interface{}, error) {
+	// Scope with super holds nothing else and the scope with this is right inside it
+	distance := i.locals[expr.id].depth
+	superclass := i.env.GetAt(distance, 0)
+	instance := i.env.GetAt(distance-1, 0)
+
+	// This is the class itself in static methods
+	if _, ok := instance.(*BluClass); ok {
+		return (superclass.(*BluClass)).get(expr.method)
+	}
+
 	method, err := (superclass.(*BluClass)).findMethod(expr.method)
 	if err != nil {
 		return nil, err
@@ -235,11 +525,11 @@ func (i *Interpreter) VisitSuperExpr(expr SuperExpr) (interface{}, error) {
 		return nil, NewRuntimeError(expr.method.line, "Undefined property '"+expr.method.lexeme+"'.")
 	}
 
-	return (method.(Function)).bind(instance.(*BluInstance)), nil
+	return (method.(Function)).bind(instance), nil
 }
 
 func (i *Interpreter) VisitThisExpr(expr ThisExpr) (interface{}, error) {
-	return i.lookUpVariable(expr.keword, expr)
+	return i.lookUpVariable(expr.keword, expr.id)
 }
 
 func (i *Interpreter) VisitUnaryExpr(expr UnaryExpr) (interface{}, error) {
@@ -250,6 +540,10 @@ func (i *Interpreter) VisitUnaryExpr(expr UnaryExpr) (interface{}, error) {
 
 	switch expr.operator.tokenType {
 	case Minus:
+		if value, ok, err := i.callSpecial(right, "__neg", expr.operator); ok || err != nil {
+			return value, err
+		}
+
 		err := i.checkNumberOperand(expr.operator, right)
 		if err != nil {
 			return nil, err
@@ -258,13 +552,20 @@ func (i *Interpreter) VisitUnaryExpr(expr UnaryExpr) (interface{}, error) {
 		return -right.(float64), nil
 	case Bang:
 		return !i.isTruthy(right), nil
+	case Tilde:
+		err := i.checkIntegerOperand(expr.operator, right)
+		if err != nil {
+			return nil, err
+		}
+
+		return float64(^int64(right.(float64))), nil
 	}
 
 	return nil, NewRuntimeError(expr.operator.line, "Error while evaluating unary operand.")
 }
 
 func (i *Interpreter) VisitVariableExpr(expr VariableExpr) (interface{}, error) {
-	return i.lookUpVariable(expr.name, expr)
+	return i.lookUpVariable(expr.name, expr.id)
 }
 
 func (i *Interpreter) VisitAssignExpr(expr AssignExpr) (interface{}, error) {
@@ -273,11 +574,26 @@ func (i *Interpreter) VisitAssignExpr(expr AssignExpr) (interface{}, error) {
 		return nil, err
 	}
 
-	if distance, ok := i.locals[expr]; ok {
-		err = i.env.AssignAt(distance, expr.name, value)
+	result := value
+	if expr.operator != nil {
+		current, err := i.lookUpVariable(expr.name, expr.id)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = i.binary(*expr.operator, current, value)
 		if err != nil {
 			return nil, err
 		}
+
+		result = value
+		if expr.postfix {
+			result = current
+		}
+	}
+
+	if local, ok := i.locals[expr.id]; ok {
+		i.env.AssignAt(local.depth, local.index, value)
 	} else {
 		err = i.globals.Assign(expr.name, value)
 		if err != nil {
@@ -285,17 +601,38 @@ func (i *Interpreter) VisitAssignExpr(expr AssignExpr) (interface{}, error) {
 		}
 	}
 
-	return value, nil
+	return result, nil
 }
 
 func (i *Interpreter) VisitLambdaExpr(expr LambdaExpr) (interface{}, error) {
 	return MakeLambda(expr, i.env), nil
 }
 
+func (i *Interpreter) VisitListExpr(expr ListExpr) (interface{}, error) {
+	elements := make([]interface{}, len(expr.elements))
+
+	for j, element := range expr.elements {
+		value, err := i.evaluate(element)
+		if err != nil {
+			return nil, err
+		}
+
+		elements[j] = value
+	}
+
+	return MakeBluList(elements), nil
+}
+
 func (i *Interpreter) VisitBlockStmnt(stmnt BlockStmnt) error {
 	return i.executeBlock(stmnt.stmnts, MakeEnv(i.env))
 }
 
+func (i *Interpreter) VisitBreakStmnt(stmnt BreakStmnt) error {
+	i.jump(flowBreak, stmnt.label)
+
+	return nil
+}
+
 func (i *Interpreter) VisitClassStmnt(stmnt ClassStmnt) error {
 	var superclass *BluClass
 
@@ -312,37 +649,112 @@ func (i *Interpreter) VisitClassStmnt(stmnt ClassStmnt) error {
 		}
 	}
 
-	err := i.env.Define(stmnt.name, nil)
-	if err != nil {
-		return err
-	}
-
-	if stmnt.superclass != nil {
-		i.env = MakeEnv(i.env)
-		err := i.env.Define(Token{lexeme: "super"}, superclass)
+	traits := make([]*BluTrait, len(stmnt.traits))
+	for j, expr := range stmnt.traits {
+		value, err := i.evaluate(expr)
 		if err != nil {
 			return err
 		}
+
+		trait, ok := value.(*BluTrait)
+		if !ok {
+			return NewRuntimeError(expr.name.line, "Can only mix in traits.")
+		}
+
+		traits[j] = trait
 	}
 
-	methods := make(map[string]Function)
-	declarations := make(map[string]Expr)
+	enclosing := i.env
 
-	for _, declaration := range stmnt.declarations {
-		declarations[declaration.name.lexeme] = declaration.initializer
+	if stmnt.superclass != nil {
+		i.env = MakeEnv(i.env)
+		_ = i.env.Define(Token{lexeme: "super"}, superclass)
 	}
 
+	methods := make(map[string]Function)
 	for _, method := range stmnt.methods {
 		methods[method.name.lexeme] = MakeFunction(method, i.env, method.name.lexeme == "init")
 	}
 
-	class := MakeBluClass(stmnt.name.lexeme, superclass, declarations, methods)
+	getters := make(map[string]Function)
+	for _, getter := range stmnt.getters {
+		getters[getter.name.lexeme] = MakeFunction(getter, i.env, false)
+	}
+
+	setters := make(map[string]Function)
+	for _, setter := range stmnt.setters {
+		setters[setter.name.lexeme] = MakeFunction(setter, i.env, false)
+	}
+
+	class := MakeBluClass(stmnt.name.lexeme, superclass, traits, i.env, stmnt.declarations, methods, getters, setters)
+
+	for _, trait := range traits {
+		for _, required := range trait.required {
+			if _, ok := class.method(required.lexeme); !ok {
+				i.env = enclosing
+				return NewRuntimeError(stmnt.name.line, fmt.Sprintf(
+					"Class '%s' must implement method '%s' required by trait '%s'.", class.name, required.lexeme, trait.name))
+			}
+		}
+	}
+
+	i.env = enclosing
 
-	if superclass != nil {
-		i.env = i.env.enclosing
+	// Class is defined before its static initializers run, so they can refer to it just like the methods
+	err := i.env.Define(stmnt.name, class)
+	if err != nil {
+		return err
 	}
 
-	return i.env.Assign(stmnt.name, class)
+	return i.initStatics(class, stmnt)
+}
+
+// initStatics defines static methods of the class and then evaluates its static fields in order
+func (i *Interpreter) initStatics(class *BluClass, stmnt ClassStmnt) error {
+	env := MakeEnv(class.closure)
+	_ = env.Define(Token{lexeme: "this"}, class)
+
+	for _, method := range stmnt.staticFns {
+		class.fields[method.name.lexeme] = MakeFunction(method, env, false)
+	}
+
+	for _, declaration := range stmnt.statics {
+		var value interface{}
+
+		if declaration.initializer != nil {
+			var err error
+
+			value, err = i.evaluateIn(declaration.initializer, env)
+			if err != nil {
+				return err
+			}
+		}
+
+		class.fields[declaration.name.lexeme] = value
+	}
+
+	return nil
+}
+
+func (i *Interpreter) VisitContinueStmnt(stmnt ContinueStmnt) error {
+	i.jump(flowContinue, stmnt.label)
+
+	return nil
+}
+
+func (i *Interpreter) VisitEnumStmnt(stmnt EnumStmnt) error {
+	enum := MakeBluEnum(stmnt.name.lexeme)
+
+	for _, variant := range stmnt.variants {
+		fields := make([]string, len(variant.fields))
+		for j, field := range variant.fields {
+			fields[j] = field.lexeme
+		}
+
+		enum.variants = append(enum.variants, MakeBluVariant(enum, variant.name.lexeme, fields))
+	}
+
+	return i.env.Define(stmnt.name, enum)
 }
 
 func (i *Interpreter) VisitExpressionStmnt(stmnt ExpressionStmnt) error {
@@ -357,6 +769,58 @@ func (i *Interpreter) VisitFnStmnt(stmnt FnStmnt) error {
 	return i.env.Define(stmnt.name, function)
 }
 
+func (i *Interpreter) VisitForInStmnt(stmnt ForInStmnt) error {
+	iterable, err := i.evaluate(stmnt.iterable)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := i.iterator(iterable, stmnt.keyword)
+	if err != nil {
+		return err
+	}
+
+	for {
+		key, value, ok, err := iterator.next()
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return nil
+		}
+
+		env := MakeEnv(i.env)
+
+		if len(stmnt.names) == 1 {
+			// Single variable holds keys of maps and values of other collections
+			if _, ok := iterable.(*BluMap); ok {
+				value = key
+			}
+
+			err = env.Define(stmnt.names[0], value)
+		} else {
+			err = env.Define(stmnt.names[0], key)
+			if err == nil {
+				err = env.Define(stmnt.names[1], value)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = i.executeBlock([]Stmnt{stmnt.body}, env)
+		if err != nil {
+			return err
+		}
+
+		if i.exitsLoop(stmnt.label) {
+			return nil
+		}
+	}
+}
+
 func (i *Interpreter) VisitIfStmnt(stmnt IfStmnt) error {
 	condition, err := i.evaluate(stmnt.condition)
 	if err != nil {
@@ -384,7 +848,12 @@ func (i *Interpreter) VisitPrintStmnt(stmnt PrintStmnt) error {
 		return err
 	}
 
-	fmt.Println(i.Stringify(value))
+	text, err := i.stringify(value)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(text)
 
 	return nil
 }
@@ -400,6 +869,10 @@ func (i *Interpreter) VisitVarStmnt(stmnt VarStmnt) error {
 		}
 	}
 
+	if stmnt.pattern != nil {
+		return i.destructure(stmnt, value)
+	}
+
 	return i.env.Define(stmnt.name, value)
 }
 
@@ -414,7 +887,67 @@ func (i *Interpreter) VisitReturnStmnt(stmnt ReturnStmnt) error {
 		}
 	}
 
-	return MakeReturner(value)
+	i.flow = flowReturn
+	i.returnValue = value
+
+	return nil
+}
+
+func (i *Interpreter) VisitThrowStmnt(stmnt ThrowStmnt) error {
+	value, err := i.evaluate(stmnt.value)
+	if err != nil {
+		return err
+	}
+
+	if instance, ok := value.(*BluInstance); ok && i.isError(instance) && instance.fields["line"] == nil {
+		instance.fields["line"] = float64(stmnt.keyword.line)
+	}
+
+	return MakeThrower(value, stmnt.keyword.line)
+}
+
+func (i *Interpreter) VisitTraitStmnt(stmnt TraitStmnt) error {
+	methods := make(map[string]Function)
+	for _, method := range stmnt.methods {
+		methods[method.name.lexeme] = MakeFunction(method, i.env, method.name.lexeme == "init")
+	}
+
+	required := make([]Token, len(stmnt.required))
+	for j, method := range stmnt.required {
+		required[j] = method.name
+	}
+
+	return i.env.Define(stmnt.name, MakeBluTrait(stmnt.name.lexeme, methods, required))
+}
+
+func (i *Interpreter) VisitTryStmnt(stmnt TryStmnt) error {
+	err := stmnt.body.Accept(i)
+
+	if stmnt.catchBody != nil {
+		if value, ok := i.caught(err); ok {
+			env := MakeEnv(i.env)
+			if stmnt.catchName != nil {
+				_ = env.Define(*stmnt.catchName, value)
+			}
+
+			err = i.executeBlock([]Stmnt{stmnt.catchBody}, env)
+		}
+	}
+
+	if stmnt.finallyBody != nil {
+		flow, returnValue, flowLabel := i.flow, i.returnValue, i.flowLabel
+		i.flow = flowNormal
+
+		// Error or jump from the finally block overrides the outcome of the try block
+		finallyErr := stmnt.finallyBody.Accept(i)
+		if finallyErr != nil || i.flow != flowNormal {
+			return finallyErr
+		}
+
+		i.flow, i.returnValue, i.flowLabel = flow, returnValue, flowLabel
+	}
+
+	return err
 }
 
 func (i *Interpreter) VisitWhileStmnt(stmnt WhileStmnt) error {
@@ -432,13 +965,102 @@ func (i *Interpreter) VisitWhileStmnt(stmnt WhileStmnt) error {
 		if err != nil {
 			return err
 		}
+
+		if i.exitsLoop(stmnt.label) {
+			return nil
+		}
+
+		if stmnt.increment != nil {
+			_, err = i.evaluate(stmnt.increment)
+			if err != nil {
+				return err
+			}
+		}
 	}
 }
 
+// exitsLoop handles break and continue targeting the loop with the label and reports whether the loop should end.
+// Jumps targeting outer loops and returns are left in place for the enclosing statements.
+func (i *Interpreter) exitsLoop(label *Token) bool {
+	switch i.flow {
+	case flowNormal:
+		return false
+	case flowBreak, flowContinue:
+		if i.flowLabel != "" && (label == nil || label.lexeme != i.flowLabel) {
+			return true
+		}
+
+		exit := i.flow == flowBreak
+		i.flow = flowNormal
+
+		return exit
+	}
+
+	return true
+}
+
+// jump starts unwinding of the enclosing statements up to the loop with the label or the innermost loop
+func (i *Interpreter) jump(flow flow, label *Token) {
+	i.flow = flow
+	i.flowLabel = ""
+
+	if label != nil {
+		i.flowLabel = label.lexeme
+	}
+}
+
+// returned ends the unwinding caused by the return statement and gives the returned value
+func (i *Interpreter) returned() interface{} {
+	if i.flow != flowReturn {
+		return nil
+	}
+
+	value := i.returnValue
+	i.flow = flowNormal
+	i.returnValue = nil
+
+	return value
+}
+
 func (i *Interpreter) evaluate(expr Expr) (interface{}, error) {
 	return expr.Accept(i)
 }
 
+// evaluateIn evaluates the expression in the environment other than the current one
+func (i *Interpreter) evaluateIn(expr Expr, env *Env) (interface{}, error) {
+	previous := i.env
+	i.env = env
+
+	value, err := expr.Accept(i)
+
+	i.env = previous
+
+	return value, err
+}
+
+// matchArm evaluates the body of the arm in the environment of its bindings when the subject matches the pattern and the guard holds
+func (i *Interpreter) matchArm(arm MatchArm, subject interface{}) (interface{}, bool, error) {
+	previous := i.env
+	i.env = MakeEnv(previous)
+
+	ok, err := i.matchPattern(arm.pattern, subject, i.env)
+
+	if ok && err == nil && arm.guard != nil {
+		var guard interface{}
+		guard, err = i.evaluate(arm.guard)
+		ok = i.isTruthy(guard)
+	}
+
+	var value interface{}
+	if ok && err == nil {
+		value, err = i.evaluate(arm.body)
+	}
+
+	i.env = previous
+
+	return value, ok, err
+}
+
 func (i *Interpreter) executeBlock(stmnts []Stmnt, env *Env) error {
 	previous := i.env
 
@@ -446,7 +1068,7 @@ func (i *Interpreter) executeBlock(stmnts []Stmnt, env *Env) error {
 
 	for _, stmnt := range stmnts {
 		err := stmnt.Accept(i)
-		if err != nil {
+		if err != nil || i.flow != flowNormal {
 			i.env = previous
 			return err
 		}
@@ -457,16 +1079,106 @@ func (i *Interpreter) executeBlock(stmnts []Stmnt, env *Env) error {
 	return nil
 }
 
-func (i *Interpreter) lookUpVariable(name Token, expr Expr) (interface{}, error) {
-	if distance, ok := i.locals[expr]; ok {
-		return i.env.GetAt(distance, name)
+func (i *Interpreter) lookUpVariable(name Token, id int) (interface{}, error) {
+	if local, ok := i.locals[id]; ok {
+		return i.env.GetAt(local.depth, local.index), nil
 	}
 
 	return i.globals.Get(name)
 }
 
-func (i *Interpreter) resolve(expr Expr, depth int) {
-	i.locals[expr] = depth
+func (i *Interpreter) resolve(id int, depth, index int) {
+	i.locals[id] = local{
+		depth: depth,
+		index: index,
+	}
+}
+
+// nativeError converts the error of native code to the RuntimeError at the line of the token
+func (i *Interpreter) nativeError(err error, token Token) error {
+	if err, ok := err.(NativeError); ok {
+		return NewRuntimeError(token.line, err.message)
+	}
+
+	return err
+}
+
+// addFrame records the call of the function in the stack of the error unwinding through it
+func (i *Interpreter) addFrame(err error, function Callable, token Token) error {
+	switch e := err.(type) {
+	case RuntimeError:
+		e.stack = append(e.stack, i.frame(function, token))
+		return e
+	case Thrower:
+		e.stack = append(e.stack, i.frame(function, token))
+		return e
+	}
+
+	return err
+}
+
+func (i *Interpreter) frame(function Callable, token Token) string {
+	return fmt.Sprintf("at %s (line %d)", function.String(), token.line)
+}
+
+// caught converts the error to the value bound by the catch clause.
+// It reports false for errors which can not be caught, such as return or exit signals.
+func (i *Interpreter) caught(err error) (interface{}, bool) {
+	switch err := err.(type) {
+	case RuntimeError:
+		instance := MakeBluInstance(i.errorClass)
+		instance.fields["message"] = err.message
+		instance.fields["line"] = float64(err.line)
+		instance.fields["stack"] = i.stackList(err.stack)
+
+		return instance, true
+	case Thrower:
+		if instance, ok := err.value.(*BluInstance); ok && i.isError(instance) {
+			instance.fields["stack"] = i.stackList(err.stack)
+		}
+
+		return err.value, true
+	}
+
+	return nil, false
+}
+
+// uncaught converts the thrown value which reached the top level to the RuntimeError
+func (i *Interpreter) uncaught(err error) error {
+	thrower, ok := err.(Thrower)
+	if !ok {
+		return err
+	}
+
+	message := i.Stringify(thrower.value)
+	if instance, ok := thrower.value.(*BluInstance); ok && i.isError(instance) {
+		message = instance.class.name + ": " + i.Stringify(instance.fields["message"])
+	}
+
+	e := NewRuntimeError(thrower.line, "Uncaught "+message)
+	e.stack = thrower.stack
+
+	return e
+}
+
+func (i *Interpreter) stackList(stack []string) *BluList {
+	elements := make([]interface{}, len(stack))
+	for j, frame := range stack {
+		elements[j] = frame
+	}
+
+	return MakeBluList(elements)
+}
+
+// isError reports whether the instance is of the Error class or of its subclass
+func (i *Interpreter) isError(instance *BluInstance) bool {
+	for class := instance.class; class != nil; class = class.superclass {
+		if class == i.errorClass {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (i *Interpreter) isTruthy(value interface{}) bool {
@@ -485,18 +1197,102 @@ func (i *Interpreter) isEqual(left, right interface{}) bool {
 		return true
 	}
 
+	if left, ok := left.(*BluEnumValue); ok {
+		if right, ok := right.(*BluEnumValue); ok {
+			return left.equals(right, i)
+		}
+	}
+
 	return left == right
 }
 
+// Stringify returns the text representation of the value.
+// Instances whose conversion method fails are represented by the name of their class.
 func (i *Interpreter) Stringify(value interface{}) string {
+	text, err := i.stringify(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return text
+}
+
+// stringify returns the text representation of the value, it calls __str or toString method of instances
+func (i *Interpreter) stringify(value interface{}) (string, error) {
 	switch value := value.(type) {
 	case nil:
-		return "null"
+		return "null", nil
 	case float64:
-		return strconv.FormatFloat(value, 'f', -1, 64)
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case *BluList:
+		if i.stringifying[value] {
+			return "[...]", nil
+		}
+
+		i.stringifying[value] = true
+		defer delete(i.stringifying, value)
+
+		elements := make([]string, len(value.elements))
+		for j, element := range value.elements {
+			text, err := i.stringify(element)
+			if err != nil {
+				return "", err
+			}
+
+			elements[j] = text
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]", nil
+	case *BluMap:
+		if i.stringifying[value] {
+			return "{...}", nil
+		}
+
+		i.stringifying[value] = true
+		defer delete(i.stringifying, value)
+
+		entries := make([]string, len(value.keys))
+		for j, key := range value.keys {
+			keyText, err := i.stringify(key)
+			if err != nil {
+				return "", err
+			}
+
+			valueText, err := i.stringify(value.values[j])
+			if err != nil {
+				return "", err
+			}
+
+			entries[j] = keyText + ": " + valueText
+		}
+
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	case *BluRange:
+		return value.String(), nil
+	case *BluEnumValue:
+		if value.variant.unit != nil {
+			return value.String(), nil
+		}
+
+		values := make([]string, len(value.values))
+		for j, element := range value.values {
+			text, err := i.stringify(element)
+			if err != nil {
+				return "", err
+			}
+
+			values[j] = text
+		}
+
+		return value.variant.enum.name + "." + value.variant.name + "(" + strings.Join(values, ", ") + ")", nil
+	case *BluInstance:
+		text, ok, err := i.stringifyInstance(value)
+		if ok || err != nil {
+			return text, err
+		}
 	}
 
-	return fmt.Sprintf("%v", value)
+	return fmt.Sprintf("%v", value), nil
 }
 
 func (i *Interpreter) checkNumberOperand(operator Token, operand interface{}) error {
@@ -508,6 +1304,49 @@ func (i *Interpreter) checkNumberOperand(operator Token, operand interface{}) er
 	return NewRuntimeError(operator.line, "Operand must be a number.")
 }
 
+// bitwise applies the bitwise operator to the operands which must be integers
+func (i *Interpreter) bitwise(operator Token, left, right interface{}) (interface{}, error) {
+	err := i.checkIntegerOperand(operator, left)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.checkIntegerOperand(operator, right)
+	if err != nil {
+		return nil, err
+	}
+
+	l, r := int64(left.(float64)), int64(right.(float64))
+
+	switch operator.tokenType {
+	case Ampersand:
+		return float64(l & r), nil
+	case Pipe:
+		return float64(l | r), nil
+	case Caret:
+		return float64(l ^ r), nil
+	}
+
+	if r < 0 {
+		return nil, NewRuntimeError(operator.line, "Shift count cannot be negative.")
+	}
+
+	if operator.tokenType == LessLess {
+		return float64(l << uint64(r)), nil
+	}
+
+	return float64(l >> uint64(r)), nil
+}
+
+// checkIntegerOperand accepts the integers representable as int64, the bytecode VM rejects the same operands
+func (i *Interpreter) checkIntegerOperand(operator Token, operand interface{}) error {
+	if number, ok := operand.(float64); ok && number == math.Trunc(number) && number >= math.MinInt64 && number < -math.MinInt64 {
+		return nil
+	}
+
+	return NewRuntimeError(operator.line, "Operands must be integers.")
+}
+
 func (i *Interpreter) checkNumberOperands(operator Token, left, right interface{}) error {
 	_, okLeft := left.(float64)
 	_, okRight := right.(float64)
@@ -0,0 +1,159 @@
+package lang
+
+// iterator yields key and value pairs of the collection looped over by the for-in statement
+type iterator interface {
+	next() (key, value interface{}, ok bool, err error)
+}
+
+type listIterator struct {
+	list  *BluList
+	index int
+}
+
+func (it *listIterator) next() (interface{}, interface{}, bool, error) {
+	if it.index >= len(it.list.elements) {
+		return nil, nil, false, nil
+	}
+
+	it.index++
+
+	return float64(it.index - 1), it.list.elements[it.index-1], true, nil
+}
+
+type mapIterator struct {
+	dictionary *BluMap
+	index      int
+}
+
+func (it *mapIterator) next() (interface{}, interface{}, bool, error) {
+	if it.index >= len(it.dictionary.keys) {
+		return nil, nil, false, nil
+	}
+
+	it.index++
+
+	return it.dictionary.keys[it.index-1], it.dictionary.values[it.index-1], true, nil
+}
+
+type stringIterator struct {
+	runes []rune
+	index int
+}
+
+func (it *stringIterator) next() (interface{}, interface{}, bool, error) {
+	if it.index >= len(it.runes) {
+		return nil, nil, false, nil
+	}
+
+	it.index++
+
+	return float64(it.index - 1), string(it.runes[it.index-1]), true, nil
+}
+
+type rangeIterator struct {
+	current float64
+	end     float64
+	index   int
+}
+
+func (it *rangeIterator) next() (interface{}, interface{}, bool, error) {
+	if it.current >= it.end {
+		return nil, nil, false, nil
+	}
+
+	it.current++
+	it.index++
+
+	return float64(it.index - 1), it.current - 1, true, nil
+}
+
+// instanceIterator calls hasNext() and next() methods of the object returned by iter()
+type instanceIterator struct {
+	interpreter *Interpreter
+	iterator    *BluInstance
+	token       Token
+	index       int
+}
+
+func (it *instanceIterator) next() (interface{}, interface{}, bool, error) {
+	hasNext, err := it.call("hasNext")
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if !it.interpreter.isTruthy(hasNext) {
+		return nil, nil, false, nil
+	}
+
+	value, err := it.call("next")
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	it.index++
+
+	return float64(it.index - 1), value, true, nil
+}
+
+func (it *instanceIterator) call(name string) (interface{}, error) {
+	token := MakeToken(Identifier, name, nil, it.token.index, it.token.line, it.token.column)
+
+	method, err := it.iterator.get(it.interpreter, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return it.interpreter.callMethod(method, token)
+}
+
+// iterator returns the iterator over the value or an error when the value cannot be looped over
+func (i *Interpreter) iterator(value interface{}, token Token) (iterator, error) {
+	switch value := value.(type) {
+	case *BluList:
+		return &listIterator{list: value}, nil
+	case *BluMap:
+		return &mapIterator{dictionary: value}, nil
+	case string:
+		return &stringIterator{runes: []rune(value)}, nil
+	case *BluRange:
+		return &rangeIterator{current: value.start, end: value.end}, nil
+	case *BluInstance:
+		iter := MakeToken(Identifier, "iter", nil, token.index, token.line, token.column)
+
+		method, err := value.get(i, iter)
+		if err != nil {
+			return nil, NewRuntimeError(token.line, "Only instances with the 'iter' method can be iterated.")
+		}
+
+		iterator, err := i.callMethod(method, iter)
+		if err != nil {
+			return nil, err
+		}
+
+		instance, ok := iterator.(*BluInstance)
+		if !ok {
+			return nil, NewRuntimeError(token.line, "Method 'iter' must return an instance.")
+		}
+
+		return &instanceIterator{interpreter: i, iterator: instance, token: token}, nil
+	}
+
+	return nil, NewRuntimeError(token.line, "Only lists, maps, strings, ranges and instances can be iterated.")
+}
+
+// callMethod calls the method which takes no arguments
+func (i *Interpreter) callMethod(method interface{}, name Token) (interface{}, error) {
+	function, ok := method.(Callable)
+	if !ok {
+		return nil, NewRuntimeError(name.line, "Property '"+name.lexeme+"' is not a method.")
+	}
+
+	arguments, err := function.Signature().bind([]interface{}{}, nil)
+	if err != nil {
+		return nil, NewRuntimeError(name.line, "Method '"+name.lexeme+"' must not take any arguments.")
+	}
+
+	value, err := function.Call(i, arguments)
+
+	return value, i.nativeError(err, name)
+}
@@ -15,25 +15,21 @@ func MakeLambda(declaration LambdaExpr, closure *Env) Lambda {
 func (f Lambda) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
 	env := MakeEnv(f.closure)
 
-	for i, argument := range arguments {
-		err := env.Define(f.declaration.params[i], argument)
-		if err != nil {
-			return nil, err
-		}
+	err := i.defineArguments(env, f.declaration.params, f.declaration.rest, arguments)
+	if err != nil {
+		return nil, err
 	}
 
-	err := i.executeBlock(f.declaration.body, env)
-	if returner, ok := err.(Returner); ok {
-		return returner.value, nil
-	} else if err != nil {
+	err = i.executeBlock(f.declaration.body, env)
+	if err != nil {
 		return nil, err
 	}
 
-	return nil, err
+	return i.returned(), nil
 }
 
-func (f Lambda) Arity() int {
-	return len(f.declaration.params)
+func (f Lambda) Signature() Signature {
+	return f.declaration.signature
 }
 
 func (f Lambda) String() string {
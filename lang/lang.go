@@ -1,10 +1,7 @@
 package lang
 
 import (
-	"bufio"
-	"fmt"
 	"io/ioutil"
-	"os"
 )
 
 // Lang is the main structure representing the language
@@ -12,78 +9,87 @@ type Lang struct {
 	scanner     Scanner
 	parser      Parser
 	resolver    Resolver
-	interpreter Interpreter
+	interpreter *Interpreter
 
-	hadError bool
+	args []string
 }
 
 // MakeLang creates new instance of the language struct
 func MakeLang() Lang {
 	interpreter := MakeInterpreter()
 
-	return Lang{
+	l := Lang{
 		scanner:     MakeScanner(),
 		parser:      MakeParser(),
 		resolver:    MakeResolver(&interpreter),
-		interpreter: interpreter,
+		interpreter: &interpreter,
+	}
 
-		hadError: false,
+	err := l.Run(prelude)
+	if err != nil {
+		panic(err)
 	}
+
+	interpreter.errorClass = interpreter.globals.globals["Error"].(*BluClass)
+
+	return l
+}
+
+// SetArgs sets command-line arguments passed to the script
+func (l *Lang) SetArgs(args []string) {
+	l.args = args
+	l.interpreter.SetArgs(args)
 }
 
 // RunFile executes source code from the file on path
-func (l *Lang) RunFile(path string) {
+func (l *Lang) RunFile(path string) error {
 	bytes, err := ioutil.ReadFile(path)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	l.run(string(bytes))
+	return l.Run(string(bytes))
+}
 
-	if l.hadError {
-		os.Exit(65)
-	}
+// RunPrompt runs code from interactive prompt until the end of input or until the script exits
+func (l *Lang) RunPrompt() error {
+	repl := MakeRepl(l)
+
+	return repl.Run()
 }
 
-// RunPrompt runs code from interactive prompt
-func (l *Lang) RunPrompt() {
-	reader := bufio.NewReader(os.Stdin)
+// Run executes the source code
+func (l *Lang) Run(source string) error {
+	stmnts, err := l.compile(source)
+	if err != nil {
+		return err
+	}
 
-	for {
-		fmt.Print("> ")
+	return l.interpreter.Interpret(stmnts)
+}
 
-		text, err := reader.ReadString('\n')
-		if err != nil {
-			return
-		}
+// Check scans, parses and resolves the source code without executing it
+func (l *Lang) Check(source string) error {
+	_, err := l.compile(source)
 
-		l.run(text)
-		l.hadError = false
-	}
+	return err
 }
 
-func (l *Lang) run(source string) {
+func (l *Lang) compile(source string) ([]Stmnt, error) {
 	tokens, err := l.scanner.ScanTokens(source)
 	if err != nil {
-		fmt.Println(err)
-		return
+		return nil, err
 	}
 
 	stmnts, err := l.parser.Parse(tokens)
 	if err != nil {
-		fmt.Println(err)
-		return
+		return nil, err
 	}
 
 	err = l.resolver.Resolve(stmnts)
 	if err != nil {
-		fmt.Println(err)
-		return
+		return nil, err
 	}
 
-	err = l.interpreter.Interpret(stmnts)
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
+	return stmnts, nil
 }
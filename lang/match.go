@@ -0,0 +1,204 @@
+package lang
+
+import (
+	"fmt"
+)
+
+// matchPattern reports whether the value matches the pattern and defines the variables bound by the pattern in the env.
+// Variables are defined in the same order the resolver declares them, the env is discarded when the match fails.
+func (i *Interpreter) matchPattern(pattern Pattern, value interface{}, env *Env) (bool, error) {
+	switch pattern := pattern.(type) {
+	case WildcardPattern:
+		return true, nil
+	case LiteralPattern:
+		return i.isEqual(pattern.value, value), nil
+	case BindingPattern:
+		return true, env.Define(pattern.name, value)
+	case ListPattern:
+		return i.matchList(pattern, value, env)
+	case MapPattern:
+		return i.matchMap(pattern, value, env)
+	case ObjectPattern:
+		return i.matchFields(pattern.names, pattern.fields, value, env)
+	case ClassPattern:
+		return i.matchClass(pattern, value, env)
+	case VariantPattern:
+		return i.matchVariant(pattern, value, env)
+	}
+
+	return false, nil
+}
+
+func (i *Interpreter) matchList(pattern ListPattern, value interface{}, env *Env) (bool, error) {
+	list, ok := value.(*BluList)
+	if !ok {
+		return false, nil
+	}
+
+	if len(list.elements) < len(pattern.elements) || (!pattern.hasRest && len(list.elements) != len(pattern.elements)) {
+		return false, nil
+	}
+
+	for j, element := range pattern.elements {
+		ok, err := i.matchPattern(element, list.elements[j], env)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+
+	if pattern.rest != nil {
+		rest := make([]interface{}, len(list.elements)-len(pattern.elements))
+		copy(rest, list.elements[len(pattern.elements):])
+
+		return true, env.Define(*pattern.rest, MakeBluList(rest))
+	}
+
+	return true, nil
+}
+
+func (i *Interpreter) matchMap(pattern MapPattern, value interface{}, env *Env) (bool, error) {
+	dictionary, ok := value.(*BluMap)
+	if !ok {
+		return false, nil
+	}
+
+	for j, key := range pattern.keys {
+		index, ok := dictionary.indexes[key]
+		if !ok {
+			return false, nil
+		}
+
+		ok, err := i.matchPattern(pattern.values[j], dictionary.values[index], env)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (i *Interpreter) matchClass(pattern ClassPattern, value interface{}, env *Env) (bool, error) {
+	target, err := i.evaluate(pattern.class)
+	if err != nil {
+		return false, err
+	}
+
+	switch target.(type) {
+	case *BluClass, *BluTrait, *BluEnum:
+	default:
+		return false, NewRuntimeError(pattern.class.name.line, "Class pattern must name a class, a trait or an enum.")
+	}
+
+	if !isInstance(value, target) {
+		return false, nil
+	}
+
+	return i.matchFields(pattern.names, pattern.fields, value, env)
+}
+
+func (i *Interpreter) matchFields(names []Token, fields []Pattern, value interface{}, env *Env) (bool, error) {
+	for j, name := range names {
+		field, ok, err := i.matchField(value, name)
+		if !ok || err != nil {
+			return false, err
+		}
+
+		ok, err = i.matchPattern(fields[j], field, env)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// matchField returns the field, the value computed by the getter or the value of the map under the name of the field.
+// Missing field fails the match instead of raising an error.
+func (i *Interpreter) matchField(value interface{}, name Token) (interface{}, bool, error) {
+	switch value := value.(type) {
+	case *BluInstance:
+		if field, ok := value.fields[name.lexeme]; ok {
+			return field, true, nil
+		}
+
+		if getter, ok := value.class.findGetter(name.lexeme); ok {
+			field, err := getter.bind(value).Call(i, nil)
+			return field, err == nil, err
+		}
+	case *BluMap:
+		if index, ok := value.indexes[name.lexeme]; ok {
+			return value.values[index], true, nil
+		}
+	case *BluEnumValue:
+		for j, field := range value.variant.fields {
+			if field == name.lexeme {
+				return value.values[j], true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (i *Interpreter) matchVariant(pattern VariantPattern, value interface{}, env *Env) (bool, error) {
+	enum, err := i.evaluate(pattern.enum)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := enum.(*BluEnum); !ok {
+		return false, NewRuntimeError(pattern.variant.line, "Variant pattern must name a variant of an enum.")
+	}
+
+	member, err := enum.(*BluEnum).get(pattern.variant)
+	if err != nil {
+		return false, err
+	}
+
+	var variant *BluVariant
+	switch member := member.(type) {
+	case *BluVariant:
+		variant = member
+	case *BluEnumValue:
+		variant = member.variant
+	}
+
+	enumValue, ok := value.(*BluEnumValue)
+	if !ok || enumValue.variant != variant {
+		return false, nil
+	}
+
+	if pattern.fields == nil {
+		return true, nil
+	}
+
+	if len(pattern.fields) != len(variant.fields) {
+		return false, NewRuntimeError(pattern.variant.line, fmt.Sprintf(
+			"Pattern of variant '%s' expects %d fields but got %d.", variant.name, len(variant.fields), len(pattern.fields),
+		))
+	}
+
+	for j, field := range pattern.fields {
+		ok, err := i.matchPattern(field, enumValue.values[j], env)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// destructure defines the variables bound by the pattern of the declaration, the value must match the pattern
+func (i *Interpreter) destructure(stmnt VarStmnt, value interface{}) error {
+	ok, err := i.matchPattern(stmnt.pattern, value, i.env)
+	if ok || err != nil {
+		return err
+	}
+
+	str, err := i.stringify(value)
+	if err != nil {
+		return err
+	}
+
+	return NewRuntimeError(stmnt.name.line, "Cannot destructure the value "+str+".")
+}
@@ -0,0 +1,18 @@
+package lang
+
+// NativeError is returned by native functions which do not know the line they were called from.
+// The interpreter converts it to the RuntimeError at the line of the call.
+type NativeError struct {
+	message string
+}
+
+func NewNativeError(message string) NativeError {
+	e := NativeError{}
+	e.message = message
+
+	return e
+}
+
+func (e NativeError) Error() string {
+	return e.message
+}
@@ -0,0 +1,36 @@
+package lang
+
+// NativeMethod is a built-in function or a method of a built-in type implemented in Go
+type NativeMethod struct {
+	name      string
+	signature Signature
+	fn        func(arguments []interface{}) (interface{}, error)
+}
+
+// MakeNativeMethod creates the native method taking exactly arity arguments
+func MakeNativeMethod(name string, arity int, fn func(arguments []interface{}) (interface{}, error)) *NativeMethod {
+	return MakeVariadicNativeMethod(name, arity, arity, fn)
+}
+
+// MakeVariadicNativeMethod creates the native method taking from min to max arguments, negative max means any number
+func MakeVariadicNativeMethod(name string, min, max int, fn func(arguments []interface{}) (interface{}, error)) *NativeMethod {
+	return &NativeMethod{
+		name:      name,
+		signature: MakeNativeSignature(min, max),
+		fn:        fn,
+	}
+}
+
+func (m *NativeMethod) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return m.fn(arguments)
+}
+
+func (m *NativeMethod) Signature() Signature {
+	return m.signature
+}
+
+func (m *NativeMethod) String() string {
+	return "<native fn " + m.name + ">"
+}
+
+var _ Callable = &NativeMethod{}
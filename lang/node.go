@@ -0,0 +1,14 @@
+package lang
+
+import (
+	"sync/atomic"
+)
+
+var lastNodeID int64
+
+// nextNodeID returns identifier unique among all syntax tree nodes of the process.
+// Nodes are value structs which are compared by their contents, the identifier tells apart
+// nodes that look the same, such as two uses of the same variable on a single line.
+func nextNodeID() int {
+	return int(atomic.AddInt64(&lastNodeID, 1))
+}
@@ -1,5 +1,9 @@
 package lang
 
+import (
+	"fmt"
+)
+
 // Parser represents the language parser
 type Parser struct {
 	tokens  []Token
@@ -30,12 +34,18 @@ func (p *Parser) Parse(tokens []Token) ([]Stmnt, error) {
 }
 
 // declaration → classDecl
+//             | traitDecl
+//             | enumDecl
 //             | fnDeclaration
 //             | varDeclaration
 //             | statement ;
 func (p *Parser) declaration() (Stmnt, error) {
 	if p.match(Class) {
 		return p.classDeclaration()
+	} else if p.match(Trait) {
+		return p.traitDeclaration()
+	} else if p.match(Enum) {
+		return p.enumDeclaration()
 	} else if p.match(Func) {
 		return p.function("function")
 	} else if p.match(Var) {
@@ -47,7 +57,10 @@ func (p *Parser) declaration() (Stmnt, error) {
 	// TODO : Synchronization
 }
 
-// classDeclaration → "class" IDENTIFIER ( "<" IDENTIFIER )? "{" ( "fn" function )* "}" ;
+// classDeclaration → "class" IDENTIFIER ( "<" IDENTIFIER )? ( "with" IDENTIFIER ( "," IDENTIFIER )* )? "{" member* "}" ;
+// member           → "static"? ( "var" varDeclaration | "fn" function )
+//                  | "get" IDENTIFIER block
+//                  | "set" function ;
 func (p *Parser) classDeclaration() (Stmnt, error) {
 	name, err := p.consume(Identifier, "Expect class name.")
 	if err != nil {
@@ -66,6 +79,22 @@ func (p *Parser) classDeclaration() (Stmnt, error) {
 		superclass = &_superclass
 	}
 
+	traits := make([]VariableExpr, 0)
+	if p.match(With) {
+		for {
+			token, err := p.consume(Identifier, "Expect trait name.")
+			if err != nil {
+				return nil, err
+			}
+
+			traits = append(traits, MakeVariableExpr(token))
+
+			if !p.match(Comma) {
+				break
+			}
+		}
+	}
+
 	_, err = p.consume(LeftBrace, "Expect '{' before class body.")
 	if err != nil {
 		return nil, err
@@ -73,32 +102,62 @@ func (p *Parser) classDeclaration() (Stmnt, error) {
 
 	methods := make([]FnStmnt, 0)
 	declarations := make([]VarStmnt, 0)
+	statics := make([]VarStmnt, 0)
+	staticFns := make([]FnStmnt, 0)
+	getters := make([]FnStmnt, 0)
+	setters := make([]FnStmnt, 0)
 
 	for !p.check(RightBrace) && !p.isAtEnd() {
-		if p.check(Var) {
-			_, err := p.consume(Var, "Expect variable declaration.")
+		isStatic := p.match(Static)
+
+		if p.match(Var) {
+			if p.check(LeftBracket) || p.check(LeftBrace) {
+				return nil, NewParserError(p.peek(), "Cannot destructure class fields.")
+			}
+
+			declaration, err := p.varDeclaration()
 			if err != nil {
 				return nil, err
 			}
 
-			declaration, err := p.varDeclaration()
+			if isStatic {
+				statics = append(statics, declaration.(VarStmnt))
+			} else {
+				declarations = append(declarations, declaration.(VarStmnt))
+			}
+		} else if p.match(Func) {
+			method, err := p.function("method")
 			if err != nil {
 				return nil, err
 			}
 
-			declarations = append(declarations, declaration.(VarStmnt))
-		} else if p.check(Func) {
-			_, err := p.consume(Func, "Expect function declaration.")
+			if isStatic {
+				staticFns = append(staticFns, method.(FnStmnt))
+			} else {
+				methods = append(methods, method.(FnStmnt))
+			}
+		} else if !isStatic && p.checkAccessor("get") {
+			getter, err := p.getter()
 			if err != nil {
 				return nil, err
 			}
 
-			method, err := p.function("method")
+			getters = append(getters, getter)
+		} else if !isStatic && p.checkAccessor("set") {
+			p.advance()
+
+			setter, err := p.function("setter")
 			if err != nil {
 				return nil, err
 			}
 
-			methods = append(methods, method.(FnStmnt))
+			if len(setter.(FnStmnt).params) != 1 || setter.(FnStmnt).rest != nil {
+				return nil, NewParserError(setter.(FnStmnt).name, "Setter must have exactly one parameter.")
+			}
+
+			setters = append(setters, setter.(FnStmnt))
+		} else if isStatic {
+			return nil, NewParserError(p.peek(), "Expect variable or function declaration after 'static'.")
 		} else {
 			return nil, NewParserError(p.tokens[p.current], "Expect variable or function declaration.")
 		}
@@ -109,21 +168,145 @@ func (p *Parser) classDeclaration() (Stmnt, error) {
 		return nil, err
 	}
 
-	return MakeClassStmnt(name, superclass, declarations, methods), nil
+	return MakeClassStmnt(name, superclass, traits, declarations, methods, statics, staticFns, getters, setters), nil
 }
 
-// function → IDENTIFIER "(" parameters? ")" block ;
-func (p *Parser) function(kind string) (Stmnt, error) {
-	name, err := p.consume(Identifier, "Expect "+kind+" name.")
+// traitDeclaration → "trait" IDENTIFIER "{" ( "fn" IDENTIFIER "(" parameters? ")" ( block | ";" ) )* "}" ;
+func (p *Parser) traitDeclaration() (Stmnt, error) {
+	name, err := p.consume(Identifier, "Expect trait name.")
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = p.consume(LeftParen, "Expect '(' after "+kind+" name.")
+	_, err = p.consume(LeftBrace, "Expect '{' before trait body.")
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make([]FnStmnt, 0)
+	required := make([]FnStmnt, 0)
+
+	for !p.check(RightBrace) && !p.isAtEnd() {
+		_, err := p.consume(Func, "Expect method declaration.")
+		if err != nil {
+			return nil, err
+		}
+
+		// Method without body has to be implemented by the class using the trait
+		if p.check(Identifier) && p.checkNext(LeftParen) && p.isRequiredMethod() {
+			method, err := p.requiredMethod()
+			if err != nil {
+				return nil, err
+			}
+
+			required = append(required, method)
+			continue
+		}
+
+		method, err := p.function("method")
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, method.(FnStmnt))
+	}
+
+	_, err = p.consume(RightBrace, "Expect '}' after trait body.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeTraitStmnt(name, methods, required), nil
+}
+
+// enumDeclaration → "enum" IDENTIFIER "{" variant ( "," variant )* ","? "}" ;
+// variant         → IDENTIFIER ( "(" parameters )? ;
+func (p *Parser) enumDeclaration() (Stmnt, error) {
+	name, err := p.consume(Identifier, "Expect enum name.")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(LeftBrace, "Expect '{' before enum body.")
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]EnumVariant, 0)
+
+	for !p.check(RightBrace) && !p.isAtEnd() {
+		variant, err := p.consume(Identifier, "Expect variant name.")
+		if err != nil {
+			return nil, err
+		}
+
+		fields := make([]Token, 0)
+		if p.match(LeftParen) {
+			fields, err = p.parameters()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		variants = append(variants, MakeEnumVariant(variant, fields))
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err = p.consume(RightBrace, "Expect '}' after enum body.")
 	if err != nil {
 		return nil, err
 	}
 
+	if len(variants) == 0 {
+		return nil, NewParserError(name, "Enum must have at least one variant.")
+	}
+
+	return MakeEnumStmnt(name, variants), nil
+}
+
+// isRequiredMethod reports whether the parameter list of the method is followed by ';' instead of the body
+func (p *Parser) isRequiredMethod() bool {
+	depth := 0
+
+	for i := p.current; i < len(p.tokens); i++ {
+		switch p.tokens[i].tokenType {
+		case LeftParen:
+			depth++
+		case RightParen:
+			depth--
+
+			if depth == 0 {
+				return i+1 < len(p.tokens) && p.tokens[i+1].tokenType == Semicolon
+			}
+		}
+	}
+
+	return false
+}
+
+// requiredMethod → IDENTIFIER "(" parameterList ";" ;
+func (p *Parser) requiredMethod() (FnStmnt, error) {
+	name := p.advance()
+	p.advance()
+
+	parameters, rest, _, err := p.parameterList()
+	if err != nil {
+		return FnStmnt{}, err
+	}
+
+	_, err = p.consume(Semicolon, "Expect ';' after required method.")
+	if err != nil {
+		return FnStmnt{}, err
+	}
+
+	return MakeFnStmnt(name, parameters, rest, make([]Stmnt, 0)), nil
+}
+
+// parameters → ( IDENTIFIER ( "," IDENTIFIER )* )? ")" ;
+func (p *Parser) parameters() ([]Token, error) {
 	parameters := make([]Token, 0)
 	if !p.check(RightParen) {
 		for {
@@ -140,7 +323,121 @@ func (p *Parser) function(kind string) (Stmnt, error) {
 		}
 	}
 
-	_, err = p.consume(RightParen, "Expect ')' after parameters.")
+	_, err := p.consume(RightParen, "Expect ')' after parameters.")
+	if err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
+// parameterList → ( parameter ( "," parameter )* ( "," "..." IDENTIFIER )? | "..." IDENTIFIER )? ")" ;
+// parameter     → ( IDENTIFIER | ( "[" | "{" ) pattern ) ( "=" expression )? ;
+//
+// Destructured parameter is passed in the hidden parameter and destructured by the declaration at the start of the body.
+func (p *Parser) parameterList() ([]Parameter, *Token, []Stmnt, error) {
+	parameters := make([]Parameter, 0)
+	destructuring := make([]Stmnt, 0)
+	var rest *Token
+
+	for !p.check(RightParen) && !p.isAtEnd() {
+		if p.match(DotDotDot) {
+			name, err := p.consume(Identifier, "Expect rest parameter name after '...'.")
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			rest = &name
+
+			break
+		}
+
+		var name Token
+		if p.check(LeftBracket) || p.check(LeftBrace) {
+			token := p.peek()
+
+			pattern, err := p.pattern()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			name = MakeToken(Identifier, fmt.Sprintf("(parameter %d)", len(parameters)), nil, token.index, token.line, token.column)
+			destructuring = append(destructuring, MakeDestructuringVarStmnt(token, pattern, MakeVariableExpr(name)))
+		} else {
+			var err error
+			name, err = p.consume(Identifier, "Exptect parameter name.")
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		var value Expr
+		if p.match(Equal) {
+			var err error
+			value, err = p.expression()
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		} else if len(parameters) > 0 && parameters[len(parameters)-1].value != nil {
+			return nil, nil, nil, NewParserError(name, "Required parameter cannot follow optional parameter.")
+		}
+
+		parameters = append(parameters, MakeParameter(name, value))
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightParen, "Expect ')' after parameters.")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return parameters, rest, destructuring, nil
+}
+
+// checkAccessor reports whether the class member is an accessor starting with the contextual keyword.
+// Words get and set are keywords only when followed by the name of the property.
+func (p *Parser) checkAccessor(keyword string) bool {
+	return p.check(Identifier) && p.peek().lexeme == keyword && p.checkNext(Identifier)
+}
+
+// getter → "get" IDENTIFIER block ;
+func (p *Parser) getter() (FnStmnt, error) {
+	p.advance()
+
+	name, err := p.consume(Identifier, "Expect getter name.")
+	if err != nil {
+		return FnStmnt{}, err
+	}
+
+	_, err = p.consume(LeftBrace, "Expect '{' before getter body.")
+	if err != nil {
+		return FnStmnt{}, err
+	}
+
+	block, err := p.block()
+	if err != nil {
+		return FnStmnt{}, err
+	}
+
+	return MakeFnStmnt(name, make([]Parameter, 0), nil, (block.(BlockStmnt)).stmnts), nil
+}
+
+// function → IDENTIFIER "(" parameterList block ;
+func (p *Parser) function(kind string) (Stmnt, error) {
+	name, err := p.consume(Identifier, "Expect "+kind+" name.")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(LeftParen, "Expect '(' after "+kind+" name.")
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, rest, destructuring, err := p.parameterList()
 	if err != nil {
 		return nil, err
 	}
@@ -155,13 +452,18 @@ func (p *Parser) function(kind string) (Stmnt, error) {
 		return nil, err
 	}
 
-	body := (block.(BlockStmnt)).stmnts
+	body := append(destructuring, (block.(BlockStmnt)).stmnts...)
 
-	return MakeFnStmnt(name, parameters, body), nil
+	return MakeFnStmnt(name, parameters, rest, body), nil
 }
 
-// varDeclaration → "var" IDENTIFIER ( "=" expression )? ";" ;
+// varDeclaration → "var" IDENTIFIER ( "=" expression )? ";"
+//                | "var" ( "[" | "{" ) pattern "=" expression ";" ;
 func (p *Parser) varDeclaration() (Stmnt, error) {
+	if p.check(LeftBracket) || p.check(LeftBrace) {
+		return p.destructuringDeclaration()
+	}
+
 	name, err := p.consume(Identifier, "Expect variable name.")
 	if err != nil {
 		return nil, err
@@ -183,25 +485,67 @@ func (p *Parser) varDeclaration() (Stmnt, error) {
 	return MakeVarStmnt(name, initializer), nil
 }
 
+func (p *Parser) destructuringDeclaration() (Stmnt, error) {
+	token := p.peek()
+
+	pattern, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(Equal, "Expect '=' after destructuring pattern.")
+	if err != nil {
+		return nil, err
+	}
+
+	initializer, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(Semicolon, "Expect ';' after variable declaration.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeDestructuringVarStmnt(token, pattern, initializer), nil
+}
+
 // statement → expressionStatement
 //           | ifStatement
 //           | forStatement
 //           | returnStatement
 //           | whileStatement
+//           | breakStatement
+//           | continueStatement
+//           | labeledStatement
+//           | throwStatement
+//           | tryStatement
 //           | printStatement
 //           | block ;
 func (p *Parser) statement() (Stmnt, error) {
 	if p.match(If) {
 		return p.ifStatement()
 	} else if p.match(For) {
-		return p.forStatement()
+		return p.forStatement(nil)
 	} else if p.match(Return) {
 		return p.returnStatement()
 	} else if p.match(While) {
-		return p.whileStatement()
+		return p.whileStatement(nil)
+	} else if p.match(Break) {
+		return p.breakStatement()
+	} else if p.match(Continue) {
+		return p.continueStatement()
+	} else if p.check(Identifier) && p.checkNext(Colon) {
+		return p.labeledStatement()
+	} else if p.match(Throw) {
+		return p.throwStatement()
+	} else if p.match(Try) {
+		return p.tryStatement()
 	} else if p.match(Print) {
 		return p.printStatement()
-	} else if p.match(LeftBrace) {
+	} else if p.check(LeftBrace) && !p.isMapLiteral() {
+		p.advance()
 		return p.block()
 	}
 
@@ -258,8 +602,13 @@ func (p *Parser) ifStatement() (Stmnt, error) {
 
 // forStmt → "for" ( varDeclaration | expressionStatement | ";" )
 //                 expression? ";"
-//                 expression? ")" block ;
-func (p *Parser) forStatement() (Stmnt, error) {
+//                 expression? ")" block
+//         | forInStatement ;
+func (p *Parser) forStatement(label *Token) (Stmnt, error) {
+	if p.check(Identifier) && (p.checkNext(In) || p.checkNext(Comma)) {
+		return p.forInStatement(label)
+	}
+
 	var err error
 	var initializer Stmnt
 	var condition Expr
@@ -304,22 +653,17 @@ func (p *Parser) forStatement() (Stmnt, error) {
 		return nil, err
 	}
 
-	block, err := p.block()
+	body, err := p.block()
 	if err != nil {
 		return nil, err
 	}
 
-	stmnts := (block.(BlockStmnt)).stmnts
-
-	if increment != nil {
-		stmnts = append(stmnts, MakeExpressionStmnt(increment))
-	}
-
 	if condition == nil {
 		condition = MakeLiteralExpr(true)
 	}
 
-	while := MakeWhileStmnt(condition, MakeBlockStmnt(stmnts))
+	// Increment is kept out of the body so that it is executed after continue as well
+	while := MakeWhileStmnt(label, condition, body, increment)
 
 	if initializer != nil {
 		return MakeBlockStmnt([]Stmnt{initializer, while}), nil
@@ -328,6 +672,50 @@ func (p *Parser) forStatement() (Stmnt, error) {
 	return while, nil
 }
 
+// forInStatement → "for" IDENTIFIER ( "," IDENTIFIER )? "in" expression block ;
+func (p *Parser) forInStatement(label *Token) (Stmnt, error) {
+	keyword := p.previous()
+	names := make([]Token, 0, 2)
+
+	name, err := p.consume(Identifier, "Expect variable name after 'for'.")
+	if err != nil {
+		return nil, err
+	}
+
+	names = append(names, name)
+
+	if p.match(Comma) {
+		name, err := p.consume(Identifier, "Expect variable name after ','.")
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+
+	_, err = p.consume(In, "Expect 'in' after loop variables.")
+	if err != nil {
+		return nil, err
+	}
+
+	iterable, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(LeftBrace, "Expect '{' after for clause.")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeForInStmnt(label, keyword, names, iterable, body), nil
+}
+
 // returnStatement → "return" expression? ";" ;
 func (p *Parser) returnStatement() (Stmnt, error) {
 	var err error
@@ -350,8 +738,8 @@ func (p *Parser) returnStatement() (Stmnt, error) {
 	return MakeReturnStmnt(keyword, value), nil
 }
 
-// whileStatement → "if" expression block ;
-func (p *Parser) whileStatement() (Stmnt, error) {
+// whileStatement → "while" expression block ;
+func (p *Parser) whileStatement(label *Token) (Stmnt, error) {
 	condition, err := p.expression()
 	if err != nil {
 		return nil, err
@@ -367,79 +755,187 @@ func (p *Parser) whileStatement() (Stmnt, error) {
 		return nil, err
 	}
 
-	return MakeWhileStmnt(condition, body), nil
+	return MakeWhileStmnt(label, condition, body, nil), nil
 }
 
-// printStatement → "print" expression ";" ;
-func (p *Parser) printStatement() (Stmnt, error) {
-	expr, err := p.expression()
+// breakStatement → "break" IDENTIFIER? ";" ;
+func (p *Parser) breakStatement() (Stmnt, error) {
+	keyword := p.previous()
+
+	label, err := p.jumpLabel("break")
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = p.consume(Semicolon, "Expected ; after value.")
+	return MakeBreakStmnt(keyword, label), nil
+}
+
+// continueStatement → "continue" IDENTIFIER? ";" ;
+func (p *Parser) continueStatement() (Stmnt, error) {
+	keyword := p.previous()
+
+	label, err := p.jumpLabel("continue")
 	if err != nil {
 		return nil, err
 	}
 
-	return MakePrintStmnt(expr), nil
+	return MakeContinueStmnt(keyword, label), nil
 }
 
-// block → "{" declaration* "}" ;
-func (p *Parser) block() (Stmnt, error) {
-	stmnts := make([]Stmnt, 0)
-
-	for !p.check(RightBrace) && !p.isAtEnd() {
-		stmnt, err := p.declaration()
-		if err != nil {
-			return nil, err
-		}
+func (p *Parser) jumpLabel(kind string) (*Token, error) {
+	var label *Token
 
-		stmnts = append(stmnts, stmnt)
+	if p.match(Identifier) {
+		token := p.previous()
+		label = &token
 	}
 
-	_, err := p.consume(RightBrace, "Expected '}' after block.")
+	_, err := p.consume(Semicolon, "Expect ';' after '"+kind+"'.")
 	if err != nil {
 		return nil, err
 	}
 
-	return MakeBlockStmnt(stmnts), nil
+	return label, nil
 }
 
-// expression → "fn" lambda
-//            | assignment ;
-func (p *Parser) expression() (Expr, error) {
-	if p.match(Func) {
-		return p.lambda()
+// labeledStatement → IDENTIFIER ":" ( whileStatement | forStatement ) ;
+func (p *Parser) labeledStatement() (Stmnt, error) {
+	label := p.advance()
+	p.advance()
+
+	if p.match(While) {
+		return p.whileStatement(&label)
+	} else if p.match(For) {
+		return p.forStatement(&label)
 	}
 
-	return p.assignment()
+	return nil, NewParserError(p.peek(), "Expect loop after label.")
 }
 
-// lambda → "fn" "(" parameters? ")" block ;
-func (p *Parser) lambda() (Expr, error) {
-	_, err := p.consume(LeftParen, "Expect '(' after 'fn'.")
+// throwStatement → "throw" expression ";" ;
+func (p *Parser) throwStatement() (Stmnt, error) {
+	keyword := p.previous()
+
+	value, err := p.expression()
 	if err != nil {
 		return nil, err
 	}
 
-	parameters := make([]Token, 0)
-	if !p.check(RightParen) {
-		for {
-			parameter, err := p.consume(Identifier, "Exptect parameter name.")
-			if err != nil {
-				return nil, err
-			}
+	_, err = p.consume(Semicolon, "Expect ';' after thrown value.")
+	if err != nil {
+		return nil, err
+	}
 
-			parameters = append(parameters, parameter)
+	return MakeThrowStmnt(keyword, value), nil
+}
 
-			if !p.match(Comma) {
-				break
-			}
+// tryStatement → "try" block ( "catch" IDENTIFIER? block )? ( "finally" block )? ;
+func (p *Parser) tryStatement() (Stmnt, error) {
+	keyword := p.previous()
+
+	_, err := p.consume(LeftBrace, "Expect '{' after 'try'.")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	var catchName *Token
+	var catchBody Stmnt
+	if p.match(Catch) {
+		if p.match(Identifier) {
+			name := p.previous()
+			catchName = &name
+		}
+
+		_, err = p.consume(LeftBrace, "Expect '{' after catch clause.")
+		if err != nil {
+			return nil, err
+		}
+
+		catchBody, err = p.block()
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	_, err = p.consume(RightParen, "Expect ')' after parameters.")
+	var finallyBody Stmnt
+	if p.match(Finally) {
+		_, err = p.consume(LeftBrace, "Expect '{' after 'finally'.")
+		if err != nil {
+			return nil, err
+		}
+
+		finallyBody, err = p.block()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if catchBody == nil && finallyBody == nil {
+		return nil, NewParserError(keyword, "Expect 'catch' or 'finally' after try block.")
+	}
+
+	return MakeTryStmnt(body, catchName, catchBody, finallyBody), nil
+}
+
+// printStatement → "print" expression ";" ;
+func (p *Parser) printStatement() (Stmnt, error) {
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(Semicolon, "Expected ; after value.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakePrintStmnt(expr), nil
+}
+
+// block → "{" declaration* "}" ;
+func (p *Parser) block() (Stmnt, error) {
+	stmnts := make([]Stmnt, 0)
+
+	for !p.check(RightBrace) && !p.isAtEnd() {
+		stmnt, err := p.declaration()
+		if err != nil {
+			return nil, err
+		}
+
+		stmnts = append(stmnts, stmnt)
+	}
+
+	_, err := p.consume(RightBrace, "Expected '}' after block.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeBlockStmnt(stmnts), nil
+}
+
+// expression → "fn" lambda
+//            | assignment ;
+func (p *Parser) expression() (Expr, error) {
+	if p.match(Func) {
+		return p.lambda()
+	}
+
+	return p.assignment()
+}
+
+// lambda → "fn" "(" parameterList block ;
+func (p *Parser) lambda() (Expr, error) {
+	_, err := p.consume(LeftParen, "Expect '(' after 'fn'.")
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, rest, destructuring, err := p.parameterList()
 	if err != nil {
 		return nil, err
 	}
@@ -454,37 +950,127 @@ func (p *Parser) lambda() (Expr, error) {
 		return nil, err
 	}
 
-	body := (block.(BlockStmnt)).stmnts
+	body := append(destructuring, (block.(BlockStmnt)).stmnts...)
+
+	return MakeLambdaExpr(parameters, rest, body), nil
+}
 
-	return MakeLambdaExpr(parameters, body), nil
+// Binary operators applied by the compound assignments
+var compoundOperators = map[TokenType]TokenType{
+	PlusEqual:    Plus,
+	MinusEqual:   Minus,
+	StarEqual:    Star,
+	SlashEqual:   Slash,
+	PercentEqual: Percent,
 }
 
-// assignment → ( call "." )? IDENTIFIER "=" assignment
-//            | or ;
+// assignment → ( call "." )? IDENTIFIER ( "=" | "+=" | "-=" | "*=" | "/=" | "%=" ) assignment
+//            | call "[" expression "]" ( "=" | "+=" | "-=" | "*=" | "/=" | "%=" ) assignment
+//            | conditional ;
 func (p *Parser) assignment() (Expr, error) {
-	expr, err := p.or()
+	expr, err := p.conditional()
 	if err != nil {
 		return nil, err
 	}
 
-	if p.match(Equal) {
+	if p.match(Equal, PlusEqual, MinusEqual, StarEqual, SlashEqual, PercentEqual) {
 		equals := p.previous()
+
 		value, err := p.assignment()
 		if err != nil {
 			return nil, err
 		}
 
-		if expr, ok := expr.(VariableExpr); ok {
-			name := expr.name
+		var operator *Token
+		if tokenType, ok := compoundOperators[equals.tokenType]; ok {
+			binary := MakeToken(tokenType, equals.lexeme[:len(equals.lexeme)-1], nil, equals.index, equals.line, equals.column)
+			operator = &binary
+		}
 
-			return MakeAssignExpr(name, value), nil
+		if assignment, ok := assignTo(expr, operator, value, false); ok {
+			return assignment, nil
 		}
 
-		if expr, ok := expr.(GetExpr); ok {
-			return MakeSetExpr(expr.object, expr.name, value), nil
+		return nil, NewParserError(equals, "Invalid assignment target.")
+	}
+
+	return expr, nil
+}
+
+// assignTo turns the variable, property or index expression into the assignment to it
+func assignTo(target Expr, operator *Token, value Expr, postfix bool) (Expr, bool) {
+	switch target := target.(type) {
+	case VariableExpr:
+		return MakeAssignExpr(target.name, operator, value, postfix), true
+	case GetExpr:
+		return MakeSetExpr(target.object, target.name, operator, value, postfix), true
+	case IndexGetExpr:
+		return MakeIndexSetExpr(target.object, target.bracket, target.index, operator, value, postfix), true
+	}
+
+	return nil, false
+}
+
+// increment turns the target of "++" or "--" into the compound assignment adding or subtracting one
+func (p *Parser) increment(operator Token, target Expr, postfix bool) (Expr, error) {
+	tokenType := Plus
+	if operator.tokenType == MinusMinus {
+		tokenType = Minus
+	}
+
+	binary := MakeToken(tokenType, operator.lexeme[:1], nil, operator.index, operator.line, operator.column)
+	if assignment, ok := assignTo(target, &binary, MakeLiteralExpr(1.0), postfix); ok {
+		return assignment, nil
+	}
+
+	return nil, NewParserError(operator, "Invalid increment target.")
+}
+
+// conditional → coalesce ( "?" expression ":" conditional )? ;
+func (p *Parser) conditional() (Expr, error) {
+	expr, err := p.coalesce()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(Question) {
+		question := p.previous()
+		thenBranch, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = p.consume(Colon, "Expect ':' after then branch of conditional expression.")
+		if err != nil {
+			return nil, err
+		}
+
+		elseBranch, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+
+		expr = MakeConditionalExpr(expr, question, thenBranch, elseBranch)
+	}
+
+	return expr, nil
+}
+
+// coalesce → logic_or ( "??" logic_or )* ;
+func (p *Parser) coalesce() (Expr, error) {
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(QuestionQuestion) {
+		operator := p.previous()
+		right, err := p.or()
+		if err != nil {
+			return nil, err
 		}
 
-		return nil, NewRuntimeError(equals.line, "Invalid assignment target.")
+		expr = MakeLogicalExpr(operator, expr, right)
 	}
 
 	return expr, nil
@@ -550,16 +1136,16 @@ func (p *Parser) equality() (Expr, error) {
 	return expr, nil
 }
 
-// comparison → addition ( ( ">" | ">=" | "<" | "<=" ) addition )* ;
+// comparison → range ( ( ">" | ">=" | "<" | "<=" | "is" ) range )* ;
 func (p *Parser) comparison() (Expr, error) {
-	expr, err := p.addition()
+	expr, err := p.rangeExpr()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.match(Greater, GreaterEqual, Less, LessEqual) {
+	for p.match(Greater, GreaterEqual, Less, LessEqual, Is) {
 		operator := p.previous()
-		right, err := p.addition()
+		right, err := p.rangeExpr()
 		if err != nil {
 			return nil, err
 		}
@@ -570,16 +1156,17 @@ func (p *Parser) comparison() (Expr, error) {
 	return expr, nil
 }
 
-// addition → multiplication ( ( "-" | "+" ) multiplication )* ;
-func (p *Parser) addition() (Expr, error) {
-	expr, err := p.multiplication()
+// range → bitwise_or ( ".." bitwise_or )? ;
+func (p *Parser) rangeExpr() (Expr, error) {
+	expr, err := p.bitwiseOr()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.match(Minus, Plus) {
+	if p.match(DotDot) {
 		operator := p.previous()
-		right, err := p.multiplication()
+
+		right, err := p.bitwiseOr()
 		if err != nil {
 			return nil, err
 		}
@@ -590,16 +1177,48 @@ func (p *Parser) addition() (Expr, error) {
 	return expr, nil
 }
 
-// multiplication → unary ( ( "/" | "*" ) unary )* ;
+// bitwise_or → bitwise_xor ( "|" bitwise_xor )* ;
+func (p *Parser) bitwiseOr() (Expr, error) {
+	return p.binary(p.bitwiseXor, Pipe)
+}
+
+// bitwise_xor → bitwise_and ( "^" bitwise_and )* ;
+func (p *Parser) bitwiseXor() (Expr, error) {
+	return p.binary(p.bitwiseAnd, Caret)
+}
+
+// bitwise_and → shift ( "&" shift )* ;
+func (p *Parser) bitwiseAnd() (Expr, error) {
+	return p.binary(p.shift, Ampersand)
+}
+
+// shift → addition ( ( "<<" | ">>" ) addition )* ;
+func (p *Parser) shift() (Expr, error) {
+	return p.binary(p.addition, LessLess, GreaterGreater)
+}
+
+// addition → multiplication ( ( "-" | "+" ) multiplication )* ;
+func (p *Parser) addition() (Expr, error) {
+	return p.binary(p.multiplication, Minus, Plus)
+}
+
+// multiplication → unary ( ( "/" | "*" | "%" | "~/" ) unary )* ;
+// Integer division is "~/" since "//" starts a comment
 func (p *Parser) multiplication() (Expr, error) {
-	expr, err := p.unary()
+	return p.binary(p.unary, Slash, Star, Percent, TildeSlash)
+}
+
+// binary parses the left-associative binary operators whose operands are parsed by the operand function
+func (p *Parser) binary(operand func() (Expr, error), operators ...TokenType) (Expr, error) {
+	expr, err := operand()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.match(Slash, Star) {
+	for p.match(operators...) {
 		operator := p.previous()
-		right, err := p.unary()
+
+		right, err := operand()
 		if err != nil {
 			return nil, err
 		}
@@ -610,11 +1229,24 @@ func (p *Parser) multiplication() (Expr, error) {
 	return expr, nil
 }
 
-// unary → ( "!" | "-" ) unary
-//       | call ;
+// unary → ( "!" | "-" | "~" ) unary
+//       | ( "++" | "--" ) unary
+//       | power ;
 func (p *Parser) unary() (Expr, error) {
-	if p.match(Bang, Minus) {
+	if p.match(PlusPlus, MinusMinus) {
 		operator := p.previous()
+
+		target, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.increment(operator, target, false)
+	}
+
+	if p.match(Bang, Minus, Tilde) {
+		operator := p.previous()
+
 		right, err := p.unary()
 		if err != nil {
 			return nil, err
@@ -623,16 +1255,45 @@ func (p *Parser) unary() (Expr, error) {
 		return MakeUnaryExpr(operator, right), nil
 	}
 
-	return p.call()
+	return p.power()
+}
+
+// power → call ( "++" | "--" )? ( "**" unary )? ;
+func (p *Parser) power() (Expr, error) {
+	expr, err := p.call()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(PlusPlus, MinusMinus) {
+		expr, err = p.increment(p.previous(), expr, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.match(StarStar) {
+		operator := p.previous()
+
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		expr = MakeBinaryExpr(expr, operator, right)
+	}
+
+	return expr, nil
 }
 
-// call → primary ( "(" arguments? ")" | "." IDENTIFIER )* ;
+// call → primary ( "(" arguments? ")" | ( "." | "?." ) IDENTIFIER | "[" expression "]" )* ;
 func (p *Parser) call() (Expr, error) {
 	expr, err := p.primary()
 	if err != nil {
 		return nil, err
 	}
 
+	optional := false
 	for {
 		if p.match(LeftParen) {
 			expr, err = p.finishCall(expr)
@@ -645,20 +1306,64 @@ func (p *Parser) call() (Expr, error) {
 				return nil, err
 			}
 
-			expr = MakeGetExpr(expr, name)
+			expr = MakeGetExpr(expr, name, false)
+		} else if p.match(QuestionDot) {
+			name, err := p.consume(Identifier, "Expect property name after '?.'.")
+			if err != nil {
+				return nil, err
+			}
+
+			expr = MakeGetExpr(expr, name, true)
+			optional = true
+		} else if p.match(LeftBracket) {
+			bracket := p.previous()
+			index, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = p.consume(RightBracket, "Expect ']' after index.")
+			if err != nil {
+				return nil, err
+			}
+
+			expr = MakeIndexGetExpr(expr, bracket, index)
 		} else {
 			break
 		}
 	}
 
+	// The whole chain is wrapped so the optional get can skip the rest of it, this also makes the chain invalid assignment target
+	if optional {
+		expr = MakeOptionalChainExpr(expr)
+	}
+
 	return expr, nil
 }
 
+// arguments → argument ( "," argument )* ;
+// argument  → ( IDENTIFIER ":" )? expression ;
 func (p *Parser) finishCall(callee Expr) (Expr, error) {
 	arguments := make([]Expr, 0)
+	names := make([]Token, 0)
 
 	if !p.check(RightParen) {
 		for {
+			if p.check(Identifier) && p.checkNext(Colon) {
+				name := p.advance()
+				p.advance()
+
+				for _, previous := range names {
+					if previous.lexeme == name.lexeme {
+						return nil, NewParserError(name, "Argument '"+name.lexeme+"' passed more than once.")
+					}
+				}
+
+				names = append(names, name)
+			} else if len(names) > 0 {
+				return nil, NewParserError(p.peek(), "Positional argument cannot follow named arguments.")
+			}
+
 			expr, err := p.expression()
 			if err != nil {
 				return nil, err
@@ -677,14 +1382,18 @@ func (p *Parser) finishCall(callee Expr) (Expr, error) {
 		return nil, err
 	}
 
-	return MakeCallExpr(callee, paren, arguments), nil
+	return MakeCallExpr(callee, paren, arguments, names), nil
 }
 
 // primary → "false" | "true" | "null"
 //         | NUMBER | STRING
+//         | interpolation
 //         | "(" expression ")"
+//         | "[" ( expression ( "," expression )* ","? )? "]"
+//         | "{" ( expression ":" expression ( "," expression ":" expression )* ","? )? "}"
 //         | IDENTIFIER
-//         | "super" "." IDENTIFIER ;
+//         | "super" "." IDENTIFIER
+//         | matchExpression ;
 func (p *Parser) primary() (Expr, error) {
 	if p.match(False) {
 		return MakeLiteralExpr(false), nil
@@ -694,6 +1403,8 @@ func (p *Parser) primary() (Expr, error) {
 		return MakeLiteralExpr(nil), nil
 	} else if p.match(Number, String) {
 		return MakeLiteralExpr(p.previous().literal), nil
+	} else if p.match(Interpolation) {
+		return p.interpolation()
 	} else if p.match(LeftParen) {
 		expr, err := p.expression()
 		if err != nil {
@@ -706,6 +1417,10 @@ func (p *Parser) primary() (Expr, error) {
 		}
 
 		return MakeGroupingExpr(expr), nil
+	} else if p.match(LeftBracket) {
+		return p.list()
+	} else if p.match(LeftBrace) {
+		return p.dictionary()
 	} else if p.match(Super) {
 		keyword := p.previous()
 		_, err := p.consume(Dot, "Expect '.' after 'super'.")
@@ -723,11 +1438,405 @@ func (p *Parser) primary() (Expr, error) {
 		return MakeThisExpr(p.previous()), nil
 	} else if p.match(Identifier) {
 		return MakeVariableExpr(p.previous()), nil
+	} else if p.match(Match) {
+		return p.matchExpression()
 	}
 
 	return nil, NewParserError(p.peek(), "Unexpected token.")
 }
 
+// interpolation → INTERPOLATION expression ( INTERPOLATION expression )* STRING ;
+// The interpolated string is lowered to the concatenation of its parts,
+// the leading string part makes the concatenation stringify the values of the expressions.
+func (p *Parser) interpolation() (Expr, error) {
+	part := p.previous()
+	plus := MakeToken(Plus, "+", nil, part.index, part.line, part.column)
+
+	var expr Expr = MakeLiteralExpr(part.literal)
+	for {
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		expr = MakeBinaryExpr(expr, plus, value)
+
+		if !p.match(Interpolation, String) {
+			return nil, NewParserError(p.peek(), "Expect '}' after interpolated expression.")
+		}
+
+		part = p.previous()
+		if part.literal != "" {
+			expr = MakeBinaryExpr(expr, plus, MakeLiteralExpr(part.literal))
+		}
+
+		if part.tokenType == String {
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) list() (Expr, error) {
+	bracket := p.previous()
+	elements := make([]Expr, 0)
+
+	for !p.check(RightBracket) && !p.isAtEnd() {
+		element, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element)
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightBracket, "Expect ']' after list elements.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeListExpr(bracket, elements), nil
+}
+
+func (p *Parser) dictionary() (Expr, error) {
+	brace := p.previous()
+	keys := make([]Expr, 0)
+	values := make([]Expr, 0)
+
+	for !p.check(RightBrace) && !p.isAtEnd() {
+		key, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = p.consume(Colon, "Expect ':' after map key.")
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightBrace, "Expect '}' after map entries.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeMapExpr(brace, keys, values), nil
+}
+
+// matchExpression → "match" expression "{" arm ( "," arm )* ","? "}" ;
+// arm             → pattern ( "if" expression )? "=>" expression ;
+func (p *Parser) matchExpression() (Expr, error) {
+	keyword := p.previous()
+
+	subject, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(LeftBrace, "Expect '{' after match subject.")
+	if err != nil {
+		return nil, err
+	}
+
+	arms := make([]MatchArm, 0)
+
+	for !p.check(RightBrace) && !p.isAtEnd() {
+		pattern, err := p.pattern()
+		if err != nil {
+			return nil, err
+		}
+
+		var guard Expr
+		if p.match(If) {
+			guard, err = p.expression()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = p.consume(FatArrow, "Expect '=>' after match pattern.")
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		arms = append(arms, MakeMatchArm(pattern, guard, body))
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err = p.consume(RightBrace, "Expect '}' after match arms.")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(arms) == 0 {
+		return nil, NewParserError(keyword, "Match must have at least one arm.")
+	}
+
+	return MakeMatchExpr(keyword, subject, arms), nil
+}
+
+// pattern → "_"
+//         | literal
+//         | IDENTIFIER
+//         | IDENTIFIER "{" fieldPatterns
+//         | IDENTIFIER "." IDENTIFIER ( "(" ( pattern ( "," pattern )* )? ")" )?
+//         | "[" ( pattern ( "," pattern )* )? ( ","? "..." IDENTIFIER? )? "]"
+//         | "{" fieldPatterns
+//         | "{" ( literal ":" pattern ( "," literal ":" pattern )* ","? )? "}" ;
+// literal → "false" | "true" | "null" | "-"? NUMBER | STRING ;
+func (p *Parser) pattern() (Pattern, error) {
+	if p.check(Identifier) && p.peek().lexeme == "_" {
+		return MakeWildcardPattern(p.advance()), nil
+	} else if p.match(Identifier) {
+		name := p.previous()
+
+		if p.match(LeftBrace) {
+			return p.classPattern(name)
+		} else if p.match(Dot) {
+			return p.variantPattern(name)
+		}
+
+		return MakeBindingPattern(name), nil
+	} else if p.match(LeftBracket) {
+		return p.listPattern()
+	} else if p.match(LeftBrace) {
+		if p.check(Identifier) {
+			return p.objectPattern()
+		}
+
+		return p.mapPattern()
+	}
+
+	return p.literalPattern("Expect pattern.")
+}
+
+func (p *Parser) literalPattern(message string) (LiteralPattern, error) {
+	if p.match(False) {
+		return MakeLiteralPattern(p.previous(), false), nil
+	} else if p.match(True) {
+		return MakeLiteralPattern(p.previous(), true), nil
+	} else if p.match(Null) {
+		return MakeLiteralPattern(p.previous(), nil), nil
+	} else if p.match(Number, String) {
+		return MakeLiteralPattern(p.previous(), p.previous().literal), nil
+	} else if p.match(Minus) {
+		number, err := p.consume(Number, "Expect number after '-' in pattern.")
+		if err != nil {
+			return LiteralPattern{}, err
+		}
+
+		return MakeLiteralPattern(number, -number.literal.(float64)), nil
+	}
+
+	return LiteralPattern{}, NewParserError(p.peek(), message)
+}
+
+func (p *Parser) classPattern(class Token) (Pattern, error) {
+	names, fields, err := p.fieldPatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeClassPattern(MakeVariableExpr(class), names, fields), nil
+}
+
+func (p *Parser) objectPattern() (Pattern, error) {
+	brace := p.previous()
+
+	names, fields, err := p.fieldPatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeObjectPattern(brace, names, fields), nil
+}
+
+// fieldPatterns → ( IDENTIFIER ( ":" pattern )? ( "," IDENTIFIER ( ":" pattern )? )* ","? )? "}" ;
+func (p *Parser) fieldPatterns() ([]Token, []Pattern, error) {
+	names := make([]Token, 0)
+	fields := make([]Pattern, 0)
+
+	for !p.check(RightBrace) && !p.isAtEnd() {
+		name, err := p.consume(Identifier, "Expect field name.")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var field Pattern = MakeBindingPattern(name)
+		if p.match(Colon) {
+			field, err = p.pattern()
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		names = append(names, name)
+		fields = append(fields, field)
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightBrace, "Expect '}' after field patterns.")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return names, fields, nil
+}
+
+func (p *Parser) variantPattern(enum Token) (Pattern, error) {
+	variant, err := p.consume(Identifier, "Expect variant name after '.'.")
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.match(LeftParen) {
+		return MakeVariantPattern(MakeVariableExpr(enum), variant, nil), nil
+	}
+
+	fields := make([]Pattern, 0)
+
+	for !p.check(RightParen) && !p.isAtEnd() {
+		field, err := p.pattern()
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err = p.consume(RightParen, "Expect ')' after variant patterns.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeVariantPattern(MakeVariableExpr(enum), variant, fields), nil
+}
+
+func (p *Parser) listPattern() (Pattern, error) {
+	bracket := p.previous()
+	elements := make([]Pattern, 0)
+	hasRest := false
+	var rest *Token
+
+	for !p.check(RightBracket) && !p.isAtEnd() {
+		if p.match(DotDotDot) {
+			hasRest = true
+
+			if p.check(Identifier) && p.peek().lexeme != "_" {
+				name := p.advance()
+				rest = &name
+			} else {
+				p.match(Identifier)
+			}
+
+			break
+		}
+
+		element, err := p.pattern()
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, element)
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightBracket, "Expect ']' after list patterns.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeListPattern(bracket, elements, hasRest, rest), nil
+}
+
+func (p *Parser) mapPattern() (Pattern, error) {
+	brace := p.previous()
+	keys := make([]interface{}, 0)
+	values := make([]Pattern, 0)
+
+	for !p.check(RightBrace) && !p.isAtEnd() {
+		key, err := p.literalPattern("Expect literal map key in pattern.")
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = p.consume(Colon, "Expect ':' after map key.")
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := p.pattern()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key.value)
+		values = append(values, value)
+
+		if !p.match(Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightBrace, "Expect '}' after map patterns.")
+	if err != nil {
+		return nil, err
+	}
+
+	return MakeMapPattern(brace, keys, values), nil
+}
+
+// isMapLiteral reports whether the "{" at the start of a statement begins a map literal instead of a block.
+// Non-empty map literal starts with a simple key followed by ":".
+func (p *Parser) isMapLiteral() bool {
+	if p.current+2 >= len(p.tokens) {
+		return false
+	}
+
+	switch p.tokens[p.current+1].tokenType {
+	case String, Number, True, False, Null:
+		return p.tokens[p.current+2].tokenType == Colon
+	case Identifier:
+		// Block can start with a labeled loop
+		return p.tokens[p.current+2].tokenType == Colon && !p.checkAt(p.current+3, While) && !p.checkAt(p.current+3, For)
+	}
+
+	return false
+}
+
 func (p *Parser) consume(tokenType TokenType, message string) (Token, error) {
 	if p.check(tokenType) {
 		return p.advance(), nil
@@ -756,6 +1865,22 @@ func (p *Parser) check(tokenType TokenType) bool {
 	return p.peek().tokenType == tokenType
 }
 
+func (p *Parser) checkNext(tokenType TokenType) bool {
+	if p.isAtEnd() {
+		return false
+	}
+
+	return p.checkAt(p.current+1, tokenType)
+}
+
+func (p *Parser) checkAt(index int, tokenType TokenType) bool {
+	if index >= len(p.tokens) {
+		return false
+	}
+
+	return p.tokens[index].tokenType == tokenType
+}
+
 func (p *Parser) advance() Token {
 	if !p.isAtEnd() {
 		p.current++
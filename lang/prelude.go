@@ -0,0 +1,14 @@
+package lang
+
+// prelude is executed by every instance of the language before any other code
+const prelude = `
+class Error {
+    var message = null;
+    var line = null;
+    var stack = null;
+
+    fn init(message) {
+        this.message = message;
+    }
+}
+`
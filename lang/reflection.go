@@ -0,0 +1,199 @@
+package lang
+
+import (
+	"sort"
+)
+
+// reflectionNatives returns native functions which inspect values at runtime
+func reflectionNatives() []*NativeMethod {
+	return []*NativeMethod{
+		MakeNativeMethod("type", 1, typeOf),
+		MakeNativeMethod("fields", 1, fieldNames),
+		MakeNativeMethod("methods", 1, methodNames),
+		MakeNativeMethod("hasField", 2, hasField),
+		MakeNativeMethod("getField", 2, getField),
+		MakeNativeMethod("setField", 3, setField),
+	}
+}
+
+// typeOf returns the class of instances and the name of the type for other values
+func typeOf(arguments []interface{}) (interface{}, error) {
+	switch value := arguments[0].(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return "bool", nil
+	case float64:
+		return "number", nil
+	case string:
+		return "string", nil
+	case *BluList:
+		return "list", nil
+	case *BluMap:
+		return "map", nil
+	case *BluRange:
+		return "range", nil
+	case *BluInstance:
+		return value.class, nil
+	case *BluClass:
+		return "class", nil
+	case *BluTrait:
+		return "trait", nil
+	case *BluEnum:
+		return "enum", nil
+	case *BluEnumValue:
+		return value.variant.enum, nil
+	}
+
+	return "function", nil
+}
+
+// isInstance reports whether the value is an instance of the class or of its subclass,
+// an instance of the class using the trait or a value of the enum
+func isInstance(value interface{}, target interface{}) bool {
+	if value, ok := value.(*BluEnumValue); ok {
+		return value.variant.enum == target
+	}
+
+	instance, ok := value.(*BluInstance)
+	if !ok {
+		return false
+	}
+
+	for class := instance.class; class != nil; class = class.superclass {
+		if class == target {
+			return true
+		}
+
+		for _, trait := range class.traits {
+			if trait == target {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func fieldNames(arguments []interface{}) (interface{}, error) {
+	fields, err := fieldsOf(arguments[0])
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	return sortedList(names), nil
+}
+
+// methodNames returns names of methods of the class including the inherited ones and the ones of traits
+func methodNames(arguments []interface{}) (interface{}, error) {
+	var class *BluClass
+
+	switch value := arguments[0].(type) {
+	case *BluClass:
+		class = value
+	case *BluInstance:
+		class = value.class
+	default:
+		return nil, NewNativeError("Only classes and instances have methods.")
+	}
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+
+	for ; class != nil; class = class.superclass {
+		methods := []map[string]Function{class.methods}
+		for _, trait := range class.traits {
+			methods = append(methods, trait.methods)
+		}
+
+		for _, methods := range methods {
+			for name := range methods {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+	}
+
+	return sortedList(names), nil
+}
+
+func hasField(arguments []interface{}) (interface{}, error) {
+	fields, name, err := fieldArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ok := fields[name]
+
+	return ok, nil
+}
+
+func getField(arguments []interface{}) (interface{}, error) {
+	fields, name, err := fieldArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := fields[name]
+	if !ok {
+		return nil, NewNativeError("Undefined field '" + name + "'.")
+	}
+
+	return value, nil
+}
+
+// setField stores the value in the field directly, setters of the class are not called
+func setField(arguments []interface{}) (interface{}, error) {
+	fields, name, err := fieldArguments(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[name] = arguments[2]
+
+	return arguments[2], nil
+}
+
+func fieldArguments(arguments []interface{}) (map[string]interface{}, string, error) {
+	fields, err := fieldsOf(arguments[0])
+	if err != nil {
+		return nil, "", err
+	}
+
+	name, ok := arguments[1].(string)
+	if !ok {
+		return nil, "", NewNativeError("Field name must be a string.")
+	}
+
+	return fields, name, nil
+}
+
+// fieldsOf returns fields of the instance or static fields of the class
+func fieldsOf(value interface{}) (map[string]interface{}, error) {
+	switch value := value.(type) {
+	case *BluInstance:
+		return value.fields, nil
+	case *BluClass:
+		return value.fields, nil
+	}
+
+	return nil, NewNativeError("Only instances and classes have fields.")
+}
+
+func sortedList(names []string) *BluList {
+	sort.Strings(names)
+
+	elements := make([]interface{}, len(names))
+	for i, name := range names {
+		elements[i] = name
+	}
+
+	return MakeBluList(elements)
+}
@@ -0,0 +1,281 @@
+package lang
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/peterh/liner"
+)
+
+const (
+	replPrompt         = "> "
+	replContinuePrompt = "... "
+	replHistoryFile    = ".blu_history"
+)
+
+const replHelp = `Enter statements or expressions, values of expressions are printed automatically.
+Input spanning multiple lines is continued until all braces are closed, empty line forces evaluation.
+
+Commands:
+  :help        Prints this help.
+  :load file   Executes the file in the current session.
+  :reset       Discards all definitions and starts a new session.
+  :env         Lists all global variables.
+  :quit        Exits the prompt.`
+
+// Repl is the interactive prompt of the language
+type Repl struct {
+	lang    *Lang
+	line    *liner.State
+	history string
+}
+
+// MakeRepl creates new interactive prompt running code in the language instance
+func MakeRepl(lang *Lang) Repl {
+	return Repl{
+		lang:    lang,
+		history: historyPath(),
+	}
+}
+
+// Run reads and executes the input until the end of input or until the script exits
+func (r *Repl) Run() error {
+	r.line = liner.NewLiner()
+	defer r.line.Close()
+
+	r.line.SetCtrlCAborts(true)
+	r.line.SetWordCompleter(r.complete)
+	r.readHistory()
+	defer r.writeHistory()
+
+	for {
+		source, err := r.read()
+		if err == io.EOF {
+			fmt.Println()
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(source) == "" {
+			continue
+		}
+
+		r.line.AppendHistory(source)
+
+		if strings.HasPrefix(strings.TrimSpace(source), ":") {
+			err := r.command(strings.Fields(source))
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err = r.eval(source)
+		if _, ok := err.(Exiter); ok {
+			return err
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+}
+
+// read reads lines until they form a complete input
+func (r *Repl) read() (string, error) {
+	prompt := replPrompt
+	source := ""
+
+	for {
+		text, err := r.line.Prompt(prompt)
+		if err == liner.ErrPromptAborted {
+			prompt = replPrompt
+			source = ""
+			continue
+		} else if err != nil {
+			return "", err
+		}
+
+		if source != "" && strings.TrimSpace(text) == "" {
+			return source, nil
+		}
+
+		if source == "" {
+			source = text
+		} else {
+			source += "\n" + text
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(source), ":") || r.isComplete(source) {
+			return source, nil
+		}
+
+		prompt = replContinuePrompt
+	}
+}
+
+// isComplete reports whether the source contains whole statements or whether more lines are expected
+func (r *Repl) isComplete(source string) bool {
+	scanner := MakeScanner()
+
+	tokens, err := scanner.ScanTokens(source)
+	if err != nil {
+		// Unterminated string or string interpolation continues on the next line
+		err, ok := err.(ScannerError)
+		return !ok || (err.message != "Unterminated string." && err.message != "Unterminated string interpolation.")
+	}
+
+	depth := 0
+	for _, token := range tokens {
+		switch token.tokenType {
+		case LeftBrace, LeftBracket, LeftParen:
+			depth++
+		case RightBrace, RightBracket, RightParen:
+			depth--
+		}
+	}
+
+	if depth > 0 {
+		return false
+	}
+
+	parser := MakeParser()
+
+	_, err = parser.Parse(tokens)
+	if err, ok := err.(ParserError); ok && err.token.tokenType == EOF {
+		// Semicolon after the last expression is optional in the prompt
+		tokens, _ = scanner.ScanTokens(source + ";")
+		_, err := parser.Parse(tokens)
+
+		return err == nil
+	}
+
+	return true
+}
+
+// eval executes the source and prints values of all expression statements
+func (r *Repl) eval(source string) error {
+	stmnts, err := r.lang.compile(source)
+	if err != nil {
+		// Semicolon after the last expression is optional in the prompt
+		withSemicolon, semicolonErr := r.lang.compile(source + ";")
+		if semicolonErr != nil {
+			return err
+		}
+
+		stmnts = withSemicolon
+	}
+
+	interpreter := r.lang.interpreter
+
+	for _, stmnt := range stmnts {
+		if stmnt, ok := stmnt.(ExpressionStmnt); ok {
+			value, err := interpreter.evaluate(stmnt.expr)
+			if err != nil {
+				return interpreter.uncaught(err)
+			}
+
+			if value != nil {
+				text, err := interpreter.stringify(value)
+				if err != nil {
+					return interpreter.uncaught(err)
+				}
+
+				fmt.Println(text)
+			}
+
+			continue
+		}
+
+		err := stmnt.Accept(interpreter)
+		if err != nil {
+			return interpreter.uncaught(err)
+		}
+	}
+
+	return nil
+}
+
+// command executes the meta-command, returned Exiter ends the prompt
+func (r *Repl) command(fields []string) error {
+	switch fields[0] {
+	case ":help":
+		fmt.Println(replHelp)
+	case ":load":
+		if len(fields) != 2 {
+			fmt.Fprintln(os.Stderr, "Usage: :load file")
+			break
+		}
+
+		err := r.lang.RunFile(fields[1])
+		if _, ok := err.(Exiter); ok {
+			return err
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	case ":reset":
+		args := r.lang.args
+		*r.lang = MakeLang()
+		r.lang.SetArgs(args)
+	case ":env":
+		globals := r.lang.interpreter.globals.globals
+
+		names := make([]string, 0, len(globals))
+		for name := range globals {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			fmt.Printf("%s = %s\n", name, r.lang.interpreter.Stringify(globals[name]))
+		}
+	case ":quit", ":exit":
+		return MakeExiter(0)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command '%s'. Type :help for the list of commands.\n", fields[0])
+	}
+
+	return nil
+}
+
+func (r *Repl) readHistory() {
+	if r.history == "" {
+		return
+	}
+
+	file, err := os.Open(r.history)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, _ = r.line.ReadHistory(file)
+}
+
+func (r *Repl) writeHistory() {
+	if r.history == "" {
+		return
+	}
+
+	file, err := os.Create(r.history)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, _ = r.line.WriteHistory(file)
+}
+
+func historyPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, replHistoryFile)
+}
@@ -1,5 +1,9 @@
 package lang
 
+import (
+	"strings"
+)
+
 type functionType uint8
 type classType uint8
 
@@ -15,25 +19,60 @@ const (
 	classNone classType = iota
 	classClass
 	classSubclass
+	classTrait
 )
 
+// variable is a local variable declared in one of the scopes of the resolver
+type variable struct {
+	index   int
+	defined bool
+
+	// Method names of the trait declared by the variable, used to detect conflicts between traits
+	methods []string
+
+	// Variant names of the enum declared by the variable, used to check exhaustiveness of matches
+	variants []string
+}
+
 type Resolver struct {
 	interpreter     *Interpreter
-	scopes          []map[string]bool
+	scopes          []map[string]*variable
+	loops           []string
 	currentFunction functionType
 	currentClass    classType
+
+	// Names of the current class and its superclass which give access to private static members
+	classNames []string
+
+	// Method names of traits declared in the global scope by the name of the trait,
+	// method names of traits declared in local scopes are kept by their variables
+	traits map[string][]string
+
+	// Variant names of enums declared in the global scope by the name of the enum,
+	// variant names of enums declared in local scopes are kept by their variables
+	enums map[string][]string
 }
 
 func MakeResolver(interpreter *Interpreter) Resolver {
 	return Resolver{
 		interpreter:     interpreter,
-		scopes:          make([]map[string]bool, 0),
+		scopes:          make([]map[string]*variable, 0),
+		loops:           make([]string, 0),
 		currentFunction: functionNone,
 		currentClass:    classNone,
+		traits:          make(map[string][]string),
+		enums:           make(map[string][]string),
 	}
 }
 
 func (r *Resolver) Resolve(stmnts []Stmnt) error {
+	// Previous run could have ended with an error in the middle of a scope
+	r.scopes = r.scopes[:0]
+	r.loops = r.loops[:0]
+	r.currentFunction = functionNone
+	r.currentClass = classNone
+	r.classNames = nil
+
 	return r.resolveStmnts(stmnts)
 }
 
@@ -50,10 +89,21 @@ func (r *Resolver) VisitBlockStmnt(stmnt BlockStmnt) error {
 	return nil
 }
 
+func (r *Resolver) VisitBreakStmnt(stmnt BreakStmnt) error {
+	return r.resolveJump(stmnt.keyword, stmnt.label)
+}
+
 func (r *Resolver) VisitClassStmnt(stmnt ClassStmnt) error {
 	enclosingClass := r.currentClass
 	r.currentClass = classClass
 
+	enclosingNames := r.classNames
+	r.classNames = []string{stmnt.name.lexeme}
+
+	if stmnt.superclass != nil {
+		r.classNames = append(r.classNames, stmnt.superclass.name.lexeme)
+	}
+
 	err := r.declare(stmnt.name)
 	if err != nil {
 		return err
@@ -74,19 +124,31 @@ func (r *Resolver) VisitClassStmnt(stmnt ClassStmnt) error {
 		}
 	}
 
+	err = r.resolveTraits(stmnt)
+	if err != nil {
+		return err
+	}
+
 	if stmnt.superclass != nil {
 		r.beginScope()
-		r.scope()["super"] = true
+		r.defineName("super")
 	}
 
 	r.beginScope()
-
-	r.scope()["this"] = true
-
-	for _, declaration := range stmnt.declarations {
-		err := r.resolveStmnt(declaration)
-		if err != nil {
-			return err
+	r.defineName("this")
+
+	// Fields are not variables, only their initializers are resolved in the scope of the class.
+	// This refers to the instance in instance members and to the class itself in static ones.
+	for _, declarations := range [][]VarStmnt{stmnt.declarations, stmnt.statics} {
+		for _, declaration := range declarations {
+			if declaration.initializer == nil {
+				continue
+			}
+
+			err := r.resolveExpr(declaration.initializer)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -103,6 +165,15 @@ func (r *Resolver) VisitClassStmnt(stmnt ClassStmnt) error {
 		}
 	}
 
+	for _, methods := range [][]FnStmnt{stmnt.staticFns, stmnt.getters, stmnt.setters} {
+		for _, method := range methods {
+			err := r.resolveFunction(method, functionMethod)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	r.endScope()
 
 	if stmnt.superclass != nil {
@@ -110,6 +181,49 @@ func (r *Resolver) VisitClassStmnt(stmnt ClassStmnt) error {
 	}
 
 	r.currentClass = enclosingClass
+	r.classNames = enclosingNames
+
+	return nil
+}
+
+func (r *Resolver) VisitContinueStmnt(stmnt ContinueStmnt) error {
+	return r.resolveJump(stmnt.keyword, stmnt.label)
+}
+
+func (r *Resolver) VisitEnumStmnt(stmnt EnumStmnt) error {
+	err := r.declare(stmnt.name)
+	if err != nil {
+		return err
+	}
+
+	r.define(stmnt.name)
+
+	names := make([]string, 0, len(stmnt.variants))
+	declared := make(map[string]bool)
+
+	for _, variant := range stmnt.variants {
+		if declared[variant.name.lexeme] {
+			return NewResolverError(variant.name, "Variant with this name already declared in this enum.")
+		}
+
+		declared[variant.name.lexeme] = true
+		names = append(names, variant.name.lexeme)
+
+		fields := make(map[string]bool)
+		for _, field := range variant.fields {
+			if fields[field.lexeme] {
+				return NewResolverError(field, "Field with this name already declared in this variant.")
+			}
+
+			fields[field.lexeme] = true
+		}
+	}
+
+	if variable := r.local(stmnt.name.lexeme); variable != nil {
+		variable.variants = names
+	} else {
+		r.enums[stmnt.name.lexeme] = names
+	}
 
 	return nil
 }
@@ -129,6 +243,39 @@ func (r *Resolver) VisitFnStmnt(stmnt FnStmnt) error {
 	return r.resolveFunction(stmnt, functionFunction)
 }
 
+func (r *Resolver) VisitForInStmnt(stmnt ForInStmnt) error {
+	err := r.resolveExpr(stmnt.iterable)
+	if err != nil {
+		return err
+	}
+
+	r.beginScope()
+
+	for _, name := range stmnt.names {
+		err := r.declare(name)
+		if err != nil {
+			return err
+		}
+
+		r.define(name)
+	}
+
+	err = r.beginLoop(stmnt.label)
+	if err != nil {
+		return err
+	}
+
+	err = r.resolveStmnt(stmnt.body)
+	if err != nil {
+		return err
+	}
+
+	r.endLoop()
+	r.endScope()
+
+	return nil
+}
+
 func (r *Resolver) VisitIfStmnt(stmnt IfStmnt) error {
 	err := r.resolveExpr(stmnt.condition)
 	if err != nil {
@@ -155,6 +302,10 @@ func (r *Resolver) VisitPrintStmnt(stmnt PrintStmnt) error {
 }
 
 func (r *Resolver) VisitVarStmnt(stmnt VarStmnt) error {
+	if stmnt.pattern != nil {
+		return r.resolveDestructuring(stmnt)
+	}
+
 	err := r.declare(stmnt.name)
 	if err != nil {
 		return err
@@ -172,6 +323,28 @@ func (r *Resolver) VisitVarStmnt(stmnt VarStmnt) error {
 	return nil
 }
 
+func (r *Resolver) resolveDestructuring(stmnt VarStmnt) error {
+	// Destructured parameters are initialized from the hidden parameter so they never read private fields
+	err := r.checkPrivatePattern(stmnt.pattern, stmnt.initializer)
+	if err != nil {
+		return err
+	}
+
+	err = r.resolvePattern(stmnt.pattern)
+	if err != nil {
+		return err
+	}
+
+	err = r.resolveExpr(stmnt.initializer)
+	if err != nil {
+		return err
+	}
+
+	r.definePattern(stmnt.pattern)
+
+	return nil
+}
+
 func (r *Resolver) VisitReturnStmnt(stmnt ReturnStmnt) error {
 	if r.currentFunction == functionNone {
 		return NewResolverError(stmnt.keyword, "Cannot return from top-level code.")
@@ -191,17 +364,119 @@ func (r *Resolver) VisitReturnStmnt(stmnt ReturnStmnt) error {
 	return nil
 }
 
+func (r *Resolver) VisitThrowStmnt(stmnt ThrowStmnt) error {
+	return r.resolveExpr(stmnt.value)
+}
+
+func (r *Resolver) VisitTraitStmnt(stmnt TraitStmnt) error {
+	err := r.declare(stmnt.name)
+	if err != nil {
+		return err
+	}
+
+	r.define(stmnt.name)
+
+	enclosingClass := r.currentClass
+	r.currentClass = classTrait
+
+	enclosingNames := r.classNames
+	r.classNames = nil
+
+	r.beginScope()
+	r.defineName("this")
+
+	names := make([]string, 0, len(stmnt.methods))
+
+	for _, method := range stmnt.methods {
+		declaration := functionMethod
+
+		if method.name.lexeme == "init" {
+			declaration = functionInit
+		}
+
+		err := r.resolveFunction(method, declaration)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, method.name.lexeme)
+	}
+
+	r.endScope()
+
+	r.currentClass = enclosingClass
+	r.classNames = enclosingNames
+
+	if variable := r.local(stmnt.name.lexeme); variable != nil {
+		variable.methods = names
+	} else {
+		r.traits[stmnt.name.lexeme] = names
+	}
+
+	return nil
+}
+
+func (r *Resolver) VisitTryStmnt(stmnt TryStmnt) error {
+	err := r.resolveStmnt(stmnt.body)
+	if err != nil {
+		return err
+	}
+
+	if stmnt.catchBody != nil {
+		r.beginScope()
+
+		if stmnt.catchName != nil {
+			err := r.declare(*stmnt.catchName)
+			if err != nil {
+				return err
+			}
+
+			r.define(*stmnt.catchName)
+		}
+
+		err := r.resolveStmnt(stmnt.catchBody)
+		if err != nil {
+			return err
+		}
+
+		r.endScope()
+	}
+
+	if stmnt.finallyBody != nil {
+		err := r.resolveStmnt(stmnt.finallyBody)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Resolver) VisitWhileStmnt(stmnt WhileStmnt) error {
 	err := r.resolveExpr(stmnt.condition)
 	if err != nil {
 		return err
 	}
 
+	err = r.beginLoop(stmnt.label)
+	if err != nil {
+		return err
+	}
+
 	err = r.resolveStmnt(stmnt.body)
 	if err != nil {
 		return err
 	}
 
+	r.endLoop()
+
+	if stmnt.increment != nil {
+		err = r.resolveExpr(stmnt.increment)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -211,7 +486,7 @@ func (r *Resolver) VisitAssignExpr(expr AssignExpr) (interface{}, error) {
 		return nil, err
 	}
 
-	r.resolveLocal(expr, expr.name)
+	r.resolveLocal(expr.id, expr.name)
 
 	return nil, nil
 }
@@ -246,7 +521,31 @@ func (r *Resolver) VisitCallExpr(expr CallExpr) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitConditionalExpr(expr ConditionalExpr) (interface{}, error) {
+	err := r.resolveExpr(expr.condition)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.resolveExpr(expr.thenBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.resolveExpr(expr.elseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitGetExpr(expr GetExpr) (interface{}, error) {
+	err := r.checkPrivate(expr.object, expr.name)
+	if err != nil {
+		return nil, err
+	}
+
 	return nil, r.resolveExpr(expr.object)
 }
 
@@ -254,10 +553,44 @@ func (r *Resolver) VisitGroupingExpr(expr GroupingExpr) (interface{}, error) {
 	return nil, r.resolveExpr(expr.expression)
 }
 
+func (r *Resolver) VisitIndexGetExpr(expr IndexGetExpr) (interface{}, error) {
+	err := r.resolveExpr(expr.object)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, r.resolveExpr(expr.index)
+}
+
+func (r *Resolver) VisitIndexSetExpr(expr IndexSetExpr) (interface{}, error) {
+	err := r.resolveExpr(expr.value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.resolveExpr(expr.object)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, r.resolveExpr(expr.index)
+}
+
 func (r *Resolver) VisitLambdaExpr(expr LambdaExpr) (interface{}, error) {
 	return nil, r.resolveLambda(expr)
 }
 
+func (r *Resolver) VisitListExpr(expr ListExpr) (interface{}, error) {
+	for _, element := range expr.elements {
+		err := r.resolveExpr(element)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) VisitLiteralExpr(expr LiteralExpr) (interface{}, error) {
 	return nil, nil
 }
@@ -276,12 +609,76 @@ func (r *Resolver) VisitLogicalExpr(expr LogicalExpr) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitMapExpr(expr MapExpr) (interface{}, error) {
+	for i := range expr.keys {
+		err := r.resolveExpr(expr.keys[i])
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.resolveExpr(expr.values[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) VisitMatchExpr(expr MatchExpr) (interface{}, error) {
+	err := r.resolveExpr(expr.subject)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arm := range expr.arms {
+		r.beginScope()
+
+		err := r.checkPrivatePattern(arm.pattern, expr.subject)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.resolvePattern(arm.pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		r.definePattern(arm.pattern)
+
+		if arm.guard != nil {
+			err = r.resolveExpr(arm.guard)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = r.resolveExpr(arm.body)
+		if err != nil {
+			return nil, err
+		}
+
+		r.endScope()
+	}
+
+	return nil, r.checkExhaustive(expr)
+}
+
+func (r *Resolver) VisitOptionalChainExpr(expr OptionalChainExpr) (interface{}, error) {
+	return nil, r.resolveExpr(expr.expr)
+}
+
 func (r *Resolver) VisitSetExpr(expr SetExpr) (interface{}, error) {
 	err := r.resolveExpr(expr.value)
 	if err != nil {
 		return nil, err
 	}
 
+	err = r.checkPrivate(expr.object, expr.name)
+	if err != nil {
+		return nil, err
+	}
+
 	err = r.resolveExpr(expr.object)
 	if err != nil {
 		return nil, err
@@ -293,11 +690,13 @@ func (r *Resolver) VisitSetExpr(expr SetExpr) (interface{}, error) {
 func (r *Resolver) VisitSuperExpr(expr SuperExpr) (interface{}, error) {
 	if r.currentClass == classNone {
 		return nil, NewResolverError(expr.keword, "Cannot use 'super' outside of a class.")
+	} else if r.currentClass == classTrait {
+		return nil, NewResolverError(expr.keword, "Cannot use 'super' in a trait.")
 	} else if r.currentClass != classSubclass {
 		return nil, NewResolverError(expr.keword, "Cannot use 'super' in a class with no superclass.")
 	}
 
-	r.resolveLocal(expr, expr.keword)
+	r.resolveLocal(expr.id, expr.keword)
 
 	return nil, nil
 }
@@ -307,7 +706,7 @@ func (r *Resolver) VisitThisExpr(expr ThisExpr) (interface{}, error) {
 		return nil, NewResolverError(expr.keword, "Cannot use 'this' outside of a class.")
 	}
 
-	r.resolveLocal(expr, expr.keword)
+	r.resolveLocal(expr.id, expr.keword)
 
 	return nil, nil
 }
@@ -318,16 +717,39 @@ func (r *Resolver) VisitUnaryExpr(expr UnaryExpr) (interface{}, error) {
 
 func (r *Resolver) VisitVariableExpr(expr VariableExpr) (interface{}, error) {
 	if len(r.scopes) != 0 {
-		if value, ok := r.scope()[expr.name.lexeme]; ok && !value {
+		if variable, ok := r.scope()[expr.name.lexeme]; ok && !variable.defined {
 			return nil, NewResolverError(expr.name, "Cannot read local variable in its own initializer.")
 		}
 	}
 
-	r.resolveLocal(expr, expr.name)
+	r.resolveLocal(expr.id, expr.name)
 
 	return nil, nil
 }
 
+// checkPrivate rejects access to members starting with an underscore through anything else
+// than this, or the name of the class for static members, inside of the class or its subclasses
+func (r *Resolver) checkPrivate(object Expr, name Token) error {
+	if !strings.HasPrefix(name.lexeme, "_") {
+		return nil
+	}
+
+	if r.currentClass != classNone {
+		switch object := object.(type) {
+		case ThisExpr:
+			return nil
+		case VariableExpr:
+			for _, className := range r.classNames {
+				if object.name.lexeme == className {
+					return nil
+				}
+			}
+		}
+	}
+
+	return NewResolverError(name, "Cannot access private member '"+name.lexeme+"' outside of its class.")
+}
+
 func (r *Resolver) declare(token Token) error {
 	if len(r.scopes) == 0 {
 		return nil
@@ -337,7 +759,7 @@ func (r *Resolver) declare(token Token) error {
 		return NewResolverError(token, "Variable with this name already declared in this scope.")
 	}
 
-	r.scope()[token.lexeme] = false
+	r.scope()[token.lexeme] = &variable{index: len(r.scope())}
 
 	return nil
 }
@@ -347,11 +769,265 @@ func (r *Resolver) define(token Token) {
 		return
 	}
 
-	r.scope()[token.lexeme] = true
+	r.scope()[token.lexeme].defined = true
+}
+
+// defineName declares and defines the variable created by the interpreter, such as this or super
+func (r *Resolver) defineName(name string) {
+	r.scope()[name] = &variable{index: len(r.scope()), defined: true}
+}
+
+// resolveTraits resolves traits used by the class and reports methods defined by more than one of them
+// which the class does not override itself
+func (r *Resolver) resolveTraits(stmnt ClassStmnt) error {
+	overridden := make(map[string]bool)
+	for _, method := range stmnt.methods {
+		overridden[method.name.lexeme] = true
+	}
+
+	definedBy := make(map[string]string)
+
+	for _, trait := range stmnt.traits {
+		err := r.resolveExpr(trait)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range r.traitMethods(trait.name.lexeme) {
+			if other, ok := definedBy[name]; ok && !overridden[name] {
+				return NewResolverError(trait.name,
+					"Method '"+name+"' is defined by both traits '"+other+"' and '"+trait.name.lexeme+"'.")
+			}
+
+			definedBy[name] = trait.name.lexeme
+		}
+	}
+
+	return nil
+}
+
+// traitMethods returns method names of the trait the name refers to in the current scope,
+// nil when the name does not refer to a trait
+func (r *Resolver) traitMethods(name string) []string {
+	if variable := r.local(name); variable != nil {
+		return variable.methods
+	}
+
+	return r.traits[name]
+}
+
+// enumVariants returns variant names of the enum the name refers to in the current scope,
+// nil when the name does not refer to an enum
+func (r *Resolver) enumVariants(name string) []string {
+	if variable := r.local(name); variable != nil {
+		return variable.variants
+	}
+
+	return r.enums[name]
+}
+
+// resolvePattern declares the variables bound by the pattern in the order the interpreter defines them
+func (r *Resolver) resolvePattern(pattern Pattern) error {
+	var err error
+
+	walkPattern(pattern, func(pattern Pattern) {
+		if err != nil {
+			return
+		}
+
+		switch pattern := pattern.(type) {
+		case BindingPattern:
+			err = r.declare(pattern.name)
+		case ListPattern:
+			if pattern.rest != nil {
+				err = r.declare(*pattern.rest)
+			}
+		case ClassPattern:
+			err = r.resolveExpr(pattern.class)
+		case VariantPattern:
+			err = r.resolveExpr(pattern.enum)
+		}
+	})
+
+	return err
+}
+
+// checkPrivatePattern applies checkPrivate to the field names of the pattern matched against the subject,
+// only the outermost fields are read from the subject itself so the nested ones are private everywhere
+func (r *Resolver) checkPrivatePattern(pattern Pattern, subject Expr) error {
+	var names []Token
+	var subpatterns []Pattern
+	switch pattern := pattern.(type) {
+	case ListPattern:
+		subpatterns = pattern.elements
+	case MapPattern:
+		subpatterns = pattern.values
+	case ObjectPattern:
+		names, subpatterns = pattern.names, pattern.fields
+	case ClassPattern:
+		names, subpatterns = pattern.names, pattern.fields
+	case VariantPattern:
+		subpatterns = pattern.fields
+	}
+
+	for _, name := range names {
+		err := r.checkPrivate(subject, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, subpattern := range subpatterns {
+		err := r.checkPrivatePattern(subpattern, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// definePattern marks the variables bound by the pattern as ready for use
+func (r *Resolver) definePattern(pattern Pattern) {
+	walkPattern(pattern, func(pattern Pattern) {
+		switch pattern := pattern.(type) {
+		case BindingPattern:
+			r.define(pattern.name)
+		case ListPattern:
+			if pattern.rest != nil {
+				r.define(*pattern.rest)
+			}
+		}
+	})
+}
+
+// walkPattern calls the function for the subpatterns from left to right and then for the pattern itself,
+// so the rest of the list is visited after the elements of the list
+func walkPattern(pattern Pattern, fn func(Pattern)) {
+	var subpatterns []Pattern
+	switch pattern := pattern.(type) {
+	case ListPattern:
+		subpatterns = pattern.elements
+	case MapPattern:
+		subpatterns = pattern.values
+	case ObjectPattern:
+		subpatterns = pattern.fields
+	case ClassPattern:
+		subpatterns = pattern.fields
+	case VariantPattern:
+		subpatterns = pattern.fields
+	}
+
+	for _, subpattern := range subpatterns {
+		walkPattern(subpattern, fn)
+	}
+
+	fn(pattern)
+}
+
+// checkExhaustive reports the variants left unhandled by the match whose arms dispatch on variants of a declared enum.
+// Matches which do not dispatch on an enum are checked at runtime only.
+func (r *Resolver) checkExhaustive(expr MatchExpr) error {
+	enum := ""
+	covered := make(map[string]bool)
+
+	for _, arm := range expr.arms {
+		if arm.guard == nil && isIrrefutable(arm.pattern) {
+			return nil
+		}
+
+		pattern, ok := arm.pattern.(VariantPattern)
+		if !ok {
+			continue
+		}
+
+		if enum == "" {
+			enum = pattern.enum.name.lexeme
+		}
+
+		if arm.guard != nil || pattern.enum.name.lexeme != enum {
+			continue
+		}
+
+		irrefutable := true
+		for _, field := range pattern.fields {
+			irrefutable = irrefutable && isIrrefutable(field)
+		}
+
+		if irrefutable {
+			covered[pattern.variant.lexeme] = true
+		}
+	}
+
+	variants := r.enumVariants(enum)
+	if variants == nil {
+		return nil
+	}
+
+	missing := make([]string, 0)
+	for _, variant := range variants {
+		if !covered[variant] {
+			missing = append(missing, variant)
+		}
+	}
+
+	if len(missing) > 0 {
+		return NewResolverError(expr.keyword, "Match on enum '"+enum+"' is not exhaustive, missing variants: "+strings.Join(missing, ", ")+".")
+	}
+
+	return nil
+}
+
+func isIrrefutable(pattern Pattern) bool {
+	switch pattern.(type) {
+	case WildcardPattern, BindingPattern:
+		return true
+	}
+
+	return false
+}
+
+func (r *Resolver) beginLoop(label *Token) error {
+	if label == nil {
+		r.loops = append(r.loops, "")
+		return nil
+	}
+
+	for _, loop := range r.loops {
+		if loop == label.lexeme {
+			return NewResolverError(*label, "Label '"+label.lexeme+"' is already used by an enclosing loop.")
+		}
+	}
+
+	r.loops = append(r.loops, label.lexeme)
+
+	return nil
+}
+
+func (r *Resolver) endLoop() {
+	r.loops = r.loops[:len(r.loops)-1]
+}
+
+func (r *Resolver) resolveJump(keyword Token, label *Token) error {
+	if len(r.loops) == 0 {
+		return NewResolverError(keyword, "Cannot use '"+keyword.lexeme+"' outside of a loop.")
+	}
+
+	if label == nil {
+		return nil
+	}
+
+	for _, loop := range r.loops {
+		if loop == label.lexeme {
+			return nil
+		}
+	}
+
+	return NewResolverError(*label, "Undefined label '"+label.lexeme+"'.")
 }
 
 func (r *Resolver) beginScope() {
-	r.scopes = append(r.scopes, make(map[string]bool))
+	r.scopes = append(r.scopes, make(map[string]*variable))
 }
 
 func (r *Resolver) endScope() {
@@ -379,10 +1055,23 @@ func (r *Resolver) resolveExpr(expr Expr) error {
 	return err
 }
 
-func (r *Resolver) resolveLocal(expr Expr, name Token) {
+// local returns the variable declaring the name in the innermost scope, nil when the name refers to a global
+func (r *Resolver) local(name string) *variable {
 	for i := len(r.scopes) - 1; i >= 0; i-- {
-		if _, ok := r.scopes[i][name.lexeme]; ok {
-			r.interpreter.resolve(expr, len(r.scopes)-1-i)
+		if variable, ok := r.scopes[i][name]; ok {
+			return variable
+		}
+	}
+
+	return nil
+}
+
+// resolveLocal records the scope distance and the slot of the local variable used by the node.
+// Variables not found in any scope are globals looked up by name.
+func (r *Resolver) resolveLocal(id int, name Token) {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if variable, ok := r.scopes[i][name.lexeme]; ok {
+			r.interpreter.resolve(id, len(r.scopes)-1-i, variable.index)
 			return
 		}
 	}
@@ -392,56 +1081,87 @@ func (r *Resolver) resolveFunction(function FnStmnt, fnType functionType) error
 	enclosingFunction := r.currentFunction
 	r.currentFunction = fnType
 
-	r.beginScope()
+	// Loops cannot be exited from the inside of a function
+	enclosingLoops := r.loops
+	r.loops = make([]string, 0)
 
-	for _, param := range function.params {
-		err := r.declare(param)
-		if err != nil {
-			return err
-		}
+	r.beginScope()
 
-		r.define(param)
+	err := r.resolveParameters(function.params, function.rest)
+	if err != nil {
+		return err
 	}
 
-	err := r.resolveStmnts(function.body)
+	err = r.resolveStmnts(function.body)
 	if err != nil {
 		return err
 	}
 
 	r.endScope()
 
+	r.loops = enclosingLoops
 	r.currentFunction = enclosingFunction
 
 	return nil
 }
 
-func (r *Resolver) resolveLambda(lambda LambdaExpr) error {
-	enclosingFunction := r.currentFunction
-	r.currentFunction = functionLambda
+// resolveParameters declares the parameters, default value is resolved before its parameter is declared
+func (r *Resolver) resolveParameters(params []Parameter, rest *Token) error {
+	for _, param := range params {
+		if param.value != nil {
+			err := r.resolveExpr(param.value)
+			if err != nil {
+				return err
+			}
+		}
 
-	r.beginScope()
+		err := r.declare(param.name)
+		if err != nil {
+			return err
+		}
+
+		r.define(param.name)
+	}
 
-	for _, param := range lambda.params {
-		err := r.declare(param)
+	if rest != nil {
+		err := r.declare(*rest)
 		if err != nil {
 			return err
 		}
 
-		r.define(param)
+		r.define(*rest)
+	}
+
+	return nil
+}
+
+func (r *Resolver) resolveLambda(lambda LambdaExpr) error {
+	enclosingFunction := r.currentFunction
+	r.currentFunction = functionLambda
+
+	enclosingLoops := r.loops
+	r.loops = make([]string, 0)
+
+	r.beginScope()
+
+	err := r.resolveParameters(lambda.params, lambda.rest)
+	if err != nil {
+		return err
 	}
 
-	err := r.resolveStmnts(lambda.body)
+	err = r.resolveStmnts(lambda.body)
 	if err != nil {
 		return err
 	}
 
 	r.endScope()
 
+	r.loops = enclosingLoops
 	r.currentFunction = enclosingFunction
 
 	return nil
 }
 
-func (r *Resolver) scope() map[string]bool {
+func (r *Resolver) scope() map[string]*variable {
 	return r.scopes[len(r.scopes)-1]
 }
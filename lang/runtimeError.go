@@ -7,6 +7,7 @@ import (
 type RuntimeError struct {
 	line    int
 	message string
+	stack   []string
 }
 
 func NewRuntimeError(line int, message string) RuntimeError {
@@ -18,5 +19,11 @@ func NewRuntimeError(line int, message string) RuntimeError {
 }
 
 func (e RuntimeError) Error() string {
-	return fmt.Sprintf("[line %v] RuntimeError: %v", e.line, e.message)
+	message := fmt.Sprintf("[line %v] RuntimeError: %v", e.line, e.message)
+
+	for _, frame := range e.stack {
+		message += "\n    " + frame
+	}
+
+	return message
 }
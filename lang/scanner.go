@@ -2,25 +2,40 @@ package lang
 
 import (
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 var keywords = map[string]TokenType{
-	"and":    And,
-	"class":  Class,
-	"else":   Else,
-	"false":  False,
-	"for":    For,
-	"fn":     Func,
-	"if":     If,
-	"null":   Null,
-	"or":     Or,
-	"print":  Print,
-	"return": Return,
-	"super":  Super,
-	"this":   This,
-	"true":   True,
-	"var":    Var,
-	"while":  While,
+	"and":      And,
+	"break":    Break,
+	"catch":    Catch,
+	"class":    Class,
+	"continue": Continue,
+	"else":     Else,
+	"enum":     Enum,
+	"false":    False,
+	"finally":  Finally,
+	"for":      For,
+	"fn":       Func,
+	"if":       If,
+	"in":       In,
+	"is":       Is,
+	"match":    Match,
+	"null":     Null,
+	"or":       Or,
+	"print":    Print,
+	"return":   Return,
+	"static":   Static,
+	"super":    Super,
+	"this":     This,
+	"throw":    Throw,
+	"trait":    Trait,
+	"true":     True,
+	"try":      Try,
+	"var":      Var,
+	"while":    While,
+	"with":     With,
 }
 
 // Scanner scans the source code and returns slice of Tokens
@@ -31,6 +46,9 @@ type Scanner struct {
 	start   int
 	current int
 	line    int
+
+	// Depths of the nested braces in the expressions of the currently open string interpolations
+	interpolations []int
 }
 
 // MakeScanner creates new scanner
@@ -46,6 +64,9 @@ func (s *Scanner) ScanTokens(source string) ([]Token, error) {
 	s.start = 0
 	s.current = 0
 	s.line = 1
+	s.interpolations = s.interpolations[:0]
+
+	s.shebang()
 
 	for !s.isAtEnd() {
 		s.start = s.current
@@ -55,6 +76,10 @@ func (s *Scanner) ScanTokens(source string) ([]Token, error) {
 		}
 	}
 
+	if len(s.interpolations) > 0 {
+		return nil, NewScannerError(s.line, "Unterminated string interpolation.")
+	}
+
 	s.tokens = append(s.tokens, MakeToken(EOF, "", nil, s.current, s.line, 0))
 
 	return s.tokens, nil
@@ -68,21 +93,96 @@ func (s *Scanner) scanToken() error {
 	case ')':
 		s.addToken(RightParen, nil)
 	case '{':
+		if len(s.interpolations) > 0 {
+			s.interpolations[len(s.interpolations)-1]++
+		}
 		s.addToken(LeftBrace, nil)
 	case '}':
+		if len(s.interpolations) > 0 {
+			depth := len(s.interpolations) - 1
+			if s.interpolations[depth] == 0 {
+				// Closing brace of the embedded expression continues the interpolated string
+				s.interpolations = s.interpolations[:depth]
+				return s.string()
+			}
+
+			s.interpolations[depth]--
+		}
 		s.addToken(RightBrace, nil)
+	case '[':
+		s.addToken(LeftBracket, nil)
+	case ']':
+		s.addToken(RightBracket, nil)
 	case ',':
 		s.addToken(Comma, nil)
+	case ':':
+		s.addToken(Colon, nil)
 	case '.':
-		s.addToken(Dot, nil)
+		if s.match('.') {
+			if s.match('.') {
+				s.addToken(DotDotDot, nil)
+			} else {
+				s.addToken(DotDot, nil)
+			}
+		} else if s.isDigit(s.peek()) {
+			s.number()
+		} else {
+			s.addToken(Dot, nil)
+		}
 	case '-':
-		s.addToken(Minus, nil)
+		if s.match('=') {
+			s.addToken(MinusEqual, nil)
+		} else if s.match('-') {
+			s.addToken(MinusMinus, nil)
+		} else {
+			s.addToken(Minus, nil)
+		}
 	case '+':
-		s.addToken(Plus, nil)
+		if s.match('=') {
+			s.addToken(PlusEqual, nil)
+		} else if s.match('+') {
+			s.addToken(PlusPlus, nil)
+		} else {
+			s.addToken(Plus, nil)
+		}
 	case ';':
 		s.addToken(Semicolon, nil)
 	case '*':
-		s.addToken(Star, nil)
+		if s.match('*') {
+			s.addToken(StarStar, nil)
+		} else if s.match('=') {
+			s.addToken(StarEqual, nil)
+		} else {
+			s.addToken(Star, nil)
+		}
+	case '%':
+		if s.match('=') {
+			s.addToken(PercentEqual, nil)
+		} else {
+			s.addToken(Percent, nil)
+		}
+	case '?':
+		if s.match('?') {
+			s.addToken(QuestionQuestion, nil)
+		} else if s.peek() == '.' && !s.isDigit(s.peekNext()) {
+			// Dot followed by a digit starts the number in the conditional like a ?.5 : 1
+			s.advance()
+			s.addToken(QuestionDot, nil)
+		} else {
+			s.addToken(Question, nil)
+		}
+	case '&':
+		s.addToken(Ampersand, nil)
+	case '|':
+		s.addToken(Pipe, nil)
+	case '^':
+		s.addToken(Caret, nil)
+	case '~':
+		if s.match('/') {
+			s.addToken(TildeSlash, nil)
+		} else {
+			s.addToken(Tilde, nil)
+		}
 	case '!':
 		if s.match('=') {
 			s.addToken(BangEqual, nil)
@@ -92,18 +192,24 @@ func (s *Scanner) scanToken() error {
 	case '=':
 		if s.match('=') {
 			s.addToken(EqualEqual, nil)
+		} else if s.match('>') {
+			s.addToken(FatArrow, nil)
 		} else {
 			s.addToken(Equal, nil)
 		}
 	case '<':
 		if s.match('=') {
 			s.addToken(LessEqual, nil)
+		} else if s.match('<') {
+			s.addToken(LessLess, nil)
 		} else {
 			s.addToken(Less, nil)
 		}
 	case '>':
 		if s.match('=') {
 			s.addToken(GreaterEqual, nil)
+		} else if s.match('>') {
+			s.addToken(GreaterGreater, nil)
 		} else {
 			s.addToken(Greater, nil)
 		}
@@ -112,6 +218,8 @@ func (s *Scanner) scanToken() error {
 			for s.peek() != '\n' && !s.isAtEnd() {
 				s.advance()
 			}
+		} else if s.match('=') {
+			s.addToken(SlashEqual, nil)
 		} else {
 			s.addToken(Slash, nil)
 		}
@@ -125,6 +233,11 @@ func (s *Scanner) scanToken() error {
 		if err != nil {
 			return err
 		}
+	case '`':
+		err := s.rawString()
+		if err != nil {
+			return err
+		}
 	default:
 		if s.isDigit(c) {
 			s.number()
@@ -138,6 +251,17 @@ func (s *Scanner) scanToken() error {
 	return nil
 }
 
+// shebang skips the "#!" interpreter line at the very beginning of the source
+func (s *Scanner) shebang() {
+	if !strings.HasPrefix(s.source, "#!") {
+		return
+	}
+
+	for s.peek() != '\n' && !s.isAtEnd() {
+		s.advance()
+	}
+}
+
 func (s *Scanner) addToken(tokenType TokenType, literal interface{}) {
 	column := s.current
 
@@ -187,8 +311,84 @@ func (s *Scanner) peekNext() rune {
 	return rune(s.source[s.current+1])
 }
 
+// string scans the string up to the closing quote or up to the next "${" which starts the embedded expression.
+// Escape sequences are replaced by the characters they represent.
 func (s *Scanner) string() error {
-	for s.peek() != '"' && !s.isAtEnd() {
+	var value strings.Builder
+
+	for !s.isAtEnd() {
+		c := s.advance()
+
+		switch c {
+		case '"':
+			s.addToken(String, value.String())
+			return nil
+		case '$':
+			if s.match('{') {
+				s.interpolations = append(s.interpolations, 0)
+				s.addToken(Interpolation, value.String())
+				return nil
+			}
+		case '\\':
+			err := s.escape(&value)
+			if err != nil {
+				return err
+			}
+			continue
+		case '\n':
+			s.line++
+		}
+
+		value.WriteByte(byte(c))
+	}
+
+	return NewScannerError(s.line, "Unterminated string.")
+}
+
+var escapes = map[rune]rune{
+	'"':  '"',
+	'$':  '$',
+	'0':  0,
+	'\\': '\\',
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+}
+
+// escape writes the character represented by the escape sequence following the backslash
+func (s *Scanner) escape(value *strings.Builder) error {
+	if s.isAtEnd() {
+		return NewScannerError(s.line, "Unterminated string.")
+	}
+
+	c := s.advance()
+	if r, ok := escapes[c]; ok {
+		value.WriteRune(r)
+		return nil
+	}
+
+	if c != 'u' || !s.match('{') {
+		return NewScannerError(s.line, "Invalid escape sequence '\\"+string(c)+"'.")
+	}
+
+	start := s.current
+	for s.peek() != '}' && !s.isAtEnd() {
+		s.advance()
+	}
+
+	code, err := strconv.ParseUint(s.source[start:s.current], 16, 32)
+	if err != nil || !s.match('}') || !utf8.ValidRune(rune(code)) {
+		return NewScannerError(s.line, "Invalid unicode escape sequence.")
+	}
+
+	value.WriteRune(rune(code))
+
+	return nil
+}
+
+// rawString scans the string enclosed in backticks, it can span multiple lines and its content is taken verbatim
+func (s *Scanner) rawString() error {
+	for s.peek() != '`' && !s.isAtEnd() {
 		if s.peek() == '\n' {
 			s.line++
 		}
@@ -212,7 +412,8 @@ func (s *Scanner) number() {
 		s.advance()
 	}
 
-	if s.peek() == '.' && s.isDigit(s.peekNext()) {
+	// Number starting with the dot like .5 has already scanned its fractional part
+	if s.source[s.start] != '.' && s.peek() == '.' && s.isDigit(s.peekNext()) {
 		s.advance()
 
 		for s.isDigit(s.peek()) {
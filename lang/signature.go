@@ -0,0 +1,139 @@
+package lang
+
+import (
+	"fmt"
+)
+
+// Signature describes the arguments accepted by the callable
+type Signature struct {
+	// Names of the parameters which can be passed by name, nil for natives which do not accept named arguments
+	names []string
+	min   int
+	// Negative max means any number of arguments
+	max int
+	// Arguments over the named parameters are collected into the list passed as the last argument
+	rest bool
+}
+
+// missingArgument marks the optional parameter whose argument was not passed, the callee evaluates its default value
+type missingArgument struct{}
+
+var missing = missingArgument{}
+
+// MakeSignature creates the signature of the function declared in the language.
+// First required parameters cannot be omitted, rest parameter collects the extra arguments.
+func MakeSignature(names []string, required int, rest bool) Signature {
+	max := len(names)
+	if rest {
+		max = -1
+	}
+
+	return Signature{
+		names: names,
+		min:   required,
+		max:   max,
+		rest:  rest,
+	}
+}
+
+// MakeNativeSignature creates the signature of the native function taking from min to max positional arguments
+func MakeNativeSignature(min, max int) Signature {
+	return Signature{
+		min: min,
+		max: max,
+	}
+}
+
+// bind arranges the arguments in the order of the parameters, the last len(names) arguments are passed by the names.
+// Omitted optional arguments are set to missing and the extra arguments are collected into the rest list.
+func (s Signature) bind(arguments []interface{}, names []Token) ([]interface{}, error) {
+	positional := len(arguments) - len(names)
+
+	if len(names) == 0 && !s.rest && positional == s.max {
+		return arguments, nil
+	}
+
+	if s.names == nil {
+		if len(names) > 0 {
+			return nil, NewNativeError("Native functions do not accept named arguments.")
+		}
+
+		if positional < s.min || (s.max >= 0 && positional > s.max) {
+			return nil, s.countError(positional)
+		}
+
+		return arguments, nil
+	}
+
+	if positional > len(s.names) && !s.rest {
+		return nil, s.countError(positional)
+	}
+
+	bound := make([]interface{}, len(s.names), len(s.names)+1)
+	for j := range bound {
+		if j < positional {
+			bound[j] = arguments[j]
+		} else {
+			bound[j] = missing
+		}
+	}
+
+	for k, name := range names {
+		j := s.index(name.lexeme)
+		if j < 0 {
+			return nil, NewNativeError("Unexpected named argument '" + name.lexeme + "'.")
+		}
+
+		if bound[j] != missing {
+			return nil, NewNativeError("Argument '" + name.lexeme + "' passed more than once.")
+		}
+
+		bound[j] = arguments[positional+k]
+	}
+
+	for j := 0; j < s.min; j++ {
+		if bound[j] != missing {
+			continue
+		}
+
+		if len(names) == 0 {
+			return nil, s.countError(positional)
+		}
+
+		return nil, NewNativeError("Missing argument '" + s.names[j] + "'.")
+	}
+
+	if s.rest {
+		rest := make([]interface{}, 0)
+		if positional > len(s.names) {
+			rest = append(rest, arguments[len(s.names):positional]...)
+		}
+
+		bound = append(bound, MakeBluList(rest))
+	}
+
+	return bound, nil
+}
+
+func (s Signature) index(name string) int {
+	for j, param := range s.names {
+		if param == name {
+			return j
+		}
+	}
+
+	return -1
+}
+
+func (s Signature) countError(count int) error {
+	switch {
+	case s.min == s.max:
+		return NewNativeError(fmt.Sprintf("Expected %d arguments but got %d.", s.min, count))
+	case s.max < 0:
+		return NewNativeError(fmt.Sprintf("Expected at least %d arguments but got %d.", s.min, count))
+	case s.max == s.min+1:
+		return NewNativeError(fmt.Sprintf("Expected %d or %d arguments but got %d.", s.min, s.max, count))
+	}
+
+	return NewNativeError(fmt.Sprintf("Expected %d to %d arguments but got %d.", s.min, s.max, count))
+}
@@ -0,0 +1,95 @@
+package lang
+
+import (
+	"fmt"
+)
+
+// Names of the special methods which implement operators for instances of classes
+var operatorMethods = map[TokenType]string{
+	Plus:         "__add",
+	Minus:        "__sub",
+	Star:         "__mul",
+	Slash:        "__div",
+	Percent:      "__mod",
+	StarStar:     "__pow",
+	EqualEqual:   "__eq",
+	BangEqual:    "__eq",
+	Less:         "__lt",
+	LessEqual:    "__le",
+	Greater:      "__gt",
+	GreaterEqual: "__ge",
+}
+
+// binaryOperator dispatches the operator to the special method when the left operand is an instance.
+// It reports false when the instance does not implement the operator.
+func (i *Interpreter) binaryOperator(operator Token, left, right interface{}) (interface{}, bool, error) {
+	name, ok := operatorMethods[operator.tokenType]
+	if !ok {
+		return nil, false, nil
+	}
+
+	value, ok, err := i.callSpecial(left, name, operator, right)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+
+	if operator.tokenType == BangEqual {
+		return !i.isTruthy(value), true, nil
+	}
+
+	return value, true, nil
+}
+
+// callSpecial calls the special method of the instance.
+// It reports false when the value is not an instance or its class does not have the method.
+func (i *Interpreter) callSpecial(object interface{}, name string, token Token, arguments ...interface{}) (interface{}, bool, error) {
+	instance, ok := object.(*BluInstance)
+	if !ok {
+		return nil, false, nil
+	}
+
+	method, ok := instance.class.method(name)
+	if !ok {
+		return nil, false, nil
+	}
+
+	bound, err := method.Signature().bind(arguments, nil)
+	if err != nil {
+		return nil, true, NewRuntimeError(token.line,
+			fmt.Sprintf("Special method '%s' must take %d arguments.", name, len(arguments)))
+	}
+
+	function := method.bind(instance)
+
+	value, err := function.Call(i, bound)
+
+	return value, true, i.addFrame(err, function, token)
+}
+
+// stringifyInstance converts the instance to string by its __str or toString method.
+// It reports false when the class has neither of them.
+func (i *Interpreter) stringifyInstance(instance *BluInstance) (string, bool, error) {
+	for _, name := range []string{"__str", "toString"} {
+		method, ok := instance.class.method(name)
+		if !ok {
+			continue
+		}
+
+		// Conversion has no place in the source, errors are reported at the declaration of the method
+		token := method.declaration.name
+
+		value, _, err := i.callSpecial(instance, name, token)
+		if err != nil {
+			return "", true, err
+		}
+
+		text, ok := value.(string)
+		if !ok {
+			return "", true, NewRuntimeError(token.line, "Method '"+name+"' must return a string.")
+		}
+
+		return text, true, nil
+	}
+
+	return "", false, nil
+}
@@ -6,13 +6,20 @@ type Stmnt interface {
 
 type StmntVisitor interface {
 	VisitBlockStmnt(BlockStmnt) error
+	VisitBreakStmnt(BreakStmnt) error
 	VisitClassStmnt(ClassStmnt) error
+	VisitContinueStmnt(ContinueStmnt) error
+	VisitEnumStmnt(EnumStmnt) error
 	VisitExpressionStmnt(ExpressionStmnt) error
 	VisitFnStmnt(FnStmnt) error
+	VisitForInStmnt(ForInStmnt) error
 	VisitIfStmnt(IfStmnt) error
 	VisitPrintStmnt(PrintStmnt) error
 	VisitVarStmnt(VarStmnt) error
 	VisitReturnStmnt(ReturnStmnt) error
+	VisitThrowStmnt(ThrowStmnt) error
+	VisitTraitStmnt(TraitStmnt) error
+	VisitTryStmnt(TryStmnt) error
 	VisitWhileStmnt(WhileStmnt) error
 }
 
@@ -30,19 +37,48 @@ func (s BlockStmnt) Accept(visitor StmntVisitor) error {
 	return visitor.VisitBlockStmnt(s)
 }
 
+type BreakStmnt struct {
+	keyword Token
+	label   *Token
+}
+
+func MakeBreakStmnt(keyword Token, label *Token) BreakStmnt {
+	return BreakStmnt{
+		keyword: keyword,
+		label:   label,
+	}
+}
+
+func (s BreakStmnt) Accept(visitor StmntVisitor) error {
+	return visitor.VisitBreakStmnt(s)
+}
+
 type ClassStmnt struct {
 	name         Token
 	superclass   *VariableExpr
+	traits       []VariableExpr
 	declarations []VarStmnt
 	methods      []FnStmnt
+	statics      []VarStmnt
+	staticFns    []FnStmnt
+	getters      []FnStmnt
+	setters      []FnStmnt
 }
 
-func MakeClassStmnt(name Token, superclass *VariableExpr, declarations []VarStmnt, methods []FnStmnt) ClassStmnt {
+func MakeClassStmnt(
+	name Token, superclass *VariableExpr, traits []VariableExpr, declarations []VarStmnt, methods []FnStmnt,
+	statics []VarStmnt, staticFns []FnStmnt, getters []FnStmnt, setters []FnStmnt,
+) ClassStmnt {
 	return ClassStmnt{
 		name:         name,
 		superclass:   superclass,
+		traits:       traits,
 		declarations: declarations,
 		methods:      methods,
+		statics:      statics,
+		staticFns:    staticFns,
+		getters:      getters,
+		setters:      setters,
 	}
 }
 
@@ -50,6 +86,51 @@ func (s ClassStmnt) Accept(visitor StmntVisitor) error {
 	return visitor.VisitClassStmnt(s)
 }
 
+type ContinueStmnt struct {
+	keyword Token
+	label   *Token
+}
+
+func MakeContinueStmnt(keyword Token, label *Token) ContinueStmnt {
+	return ContinueStmnt{
+		keyword: keyword,
+		label:   label,
+	}
+}
+
+func (s ContinueStmnt) Accept(visitor StmntVisitor) error {
+	return visitor.VisitContinueStmnt(s)
+}
+
+type EnumStmnt struct {
+	name     Token
+	variants []EnumVariant
+}
+
+func MakeEnumStmnt(name Token, variants []EnumVariant) EnumStmnt {
+	return EnumStmnt{
+		name:     name,
+		variants: variants,
+	}
+}
+
+func (s EnumStmnt) Accept(visitor StmntVisitor) error {
+	return visitor.VisitEnumStmnt(s)
+}
+
+// EnumVariant is the declaration of a single variant inside of the enum statement
+type EnumVariant struct {
+	name   Token
+	fields []Token
+}
+
+func MakeEnumVariant(name Token, fields []Token) EnumVariant {
+	return EnumVariant{
+		name:   name,
+		fields: fields,
+	}
+}
+
 type ExpressionStmnt struct {
 	expr Expr
 }
@@ -65,16 +146,20 @@ func (s ExpressionStmnt) Accept(visitor StmntVisitor) error {
 }
 
 type FnStmnt struct {
-	name   Token
-	params []Token
-	body   []Stmnt
+	name      Token
+	params    []Parameter
+	rest      *Token
+	body      []Stmnt
+	signature Signature
 }
 
-func MakeFnStmnt(name Token, params []Token, body []Stmnt) FnStmnt {
+func MakeFnStmnt(name Token, params []Parameter, rest *Token, body []Stmnt) FnStmnt {
 	return FnStmnt{
-		name:   name,
-		params: params,
-		body:   body,
+		name:      name,
+		params:    params,
+		rest:      rest,
+		body:      body,
+		signature: makeParameterSignature(params, rest),
 	}
 }
 
@@ -82,6 +167,56 @@ func (s FnStmnt) Accept(visitor StmntVisitor) error {
 	return visitor.VisitFnStmnt(s)
 }
 
+// Parameter of the function, value is the default value of the optional parameter or nil
+type Parameter struct {
+	name  Token
+	value Expr
+}
+
+func MakeParameter(name Token, value Expr) Parameter {
+	return Parameter{
+		name:  name,
+		value: value,
+	}
+}
+
+func makeParameterSignature(params []Parameter, rest *Token) Signature {
+	names := make([]string, len(params))
+	required := 0
+
+	for j, param := range params {
+		names[j] = param.name.lexeme
+
+		if param.value == nil {
+			required++
+		}
+	}
+
+	return MakeSignature(names, required, rest != nil)
+}
+
+type ForInStmnt struct {
+	label    *Token
+	keyword  Token
+	names    []Token
+	iterable Expr
+	body     Stmnt
+}
+
+func MakeForInStmnt(label *Token, keyword Token, names []Token, iterable Expr, body Stmnt) ForInStmnt {
+	return ForInStmnt{
+		label:    label,
+		keyword:  keyword,
+		names:    names,
+		iterable: iterable,
+		body:     body,
+	}
+}
+
+func (s ForInStmnt) Accept(visitor StmntVisitor) error {
+	return visitor.VisitForInStmnt(s)
+}
+
 type IfStmnt struct {
 	condition  Expr
 	thenBranch Stmnt
@@ -114,8 +249,11 @@ func (s PrintStmnt) Accept(visitor StmntVisitor) error {
 	return visitor.VisitPrintStmnt(s)
 }
 
+// VarStmnt declares the variable, or the variables bound by the pattern when the pattern is set.
+// Name of the destructuring declaration is the first token of the pattern.
 type VarStmnt struct {
 	name        Token
+	pattern     Pattern
 	initializer Expr
 }
 
@@ -126,6 +264,14 @@ func MakeVarStmnt(name Token, initializer Expr) VarStmnt {
 	}
 }
 
+func MakeDestructuringVarStmnt(token Token, pattern Pattern, initializer Expr) VarStmnt {
+	return VarStmnt{
+		name:        token,
+		pattern:     pattern,
+		initializer: initializer,
+	}
+}
+
 func (s VarStmnt) Accept(visitor StmntVisitor) error {
 	return visitor.VisitVarStmnt(s)
 }
@@ -146,15 +292,73 @@ func (s ReturnStmnt) Accept(visitor StmntVisitor) error {
 	return visitor.VisitReturnStmnt(s)
 }
 
+type ThrowStmnt struct {
+	keyword Token
+	value   Expr
+}
+
+func MakeThrowStmnt(keyword Token, value Expr) ThrowStmnt {
+	return ThrowStmnt{
+		keyword: keyword,
+		value:   value,
+	}
+}
+
+func (s ThrowStmnt) Accept(visitor StmntVisitor) error {
+	return visitor.VisitThrowStmnt(s)
+}
+
+type TraitStmnt struct {
+	name     Token
+	methods  []FnStmnt
+	required []FnStmnt
+}
+
+func MakeTraitStmnt(name Token, methods []FnStmnt, required []FnStmnt) TraitStmnt {
+	return TraitStmnt{
+		name:     name,
+		methods:  methods,
+		required: required,
+	}
+}
+
+func (s TraitStmnt) Accept(visitor StmntVisitor) error {
+	return visitor.VisitTraitStmnt(s)
+}
+
+type TryStmnt struct {
+	body        Stmnt
+	catchName   *Token
+	catchBody   Stmnt
+	finallyBody Stmnt
+}
+
+func MakeTryStmnt(body Stmnt, catchName *Token, catchBody, finallyBody Stmnt) TryStmnt {
+	return TryStmnt{
+		body:        body,
+		catchName:   catchName,
+		catchBody:   catchBody,
+		finallyBody: finallyBody,
+	}
+}
+
+func (s TryStmnt) Accept(visitor StmntVisitor) error {
+	return visitor.VisitTryStmnt(s)
+}
+
 type WhileStmnt struct {
+	label     *Token
 	condition Expr
 	body      Stmnt
+	increment Expr
 }
 
-func MakeWhileStmnt(condition Expr, body Stmnt) WhileStmnt {
+func MakeWhileStmnt(label *Token, condition Expr, body Stmnt, increment Expr) WhileStmnt {
 	return WhileStmnt{
+		label:     label,
 		condition: condition,
 		body:      body,
+		increment: increment,
 	}
 }
 
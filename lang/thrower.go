@@ -0,0 +1,24 @@
+package lang
+
+import (
+	"fmt"
+)
+
+// Thrower unwinds the execution of the script when a value is thrown by the throw statement
+type Thrower struct {
+	value interface{}
+	line  int
+	stack []string
+}
+
+func MakeThrower(value interface{}, line int) Thrower {
+	return Thrower{
+		value: value,
+		line:  line,
+		stack: make([]string, 0),
+	}
+}
+
+func (t Thrower) Error() string {
+	return fmt.Sprintf("THROW: %v", t.value)
+}
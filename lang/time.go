@@ -11,8 +11,8 @@ func (t Time) Call(i *Interpreter, arguments []interface{}) (interface{}, error)
 	return float64(time.Now().UnixNano() / 1000), nil
 }
 
-func (t Time) Arity() int {
-	return 0
+func (t Time) Signature() Signature {
+	return MakeNativeSignature(0, 0)
 }
 
 func (t Time) String() string {
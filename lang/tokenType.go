@@ -6,50 +6,90 @@ type TokenType string
 // List of all tokens
 const (
 	// Single character tokens
-	Comma      TokenType = "COMMA"
-	Dot        TokenType = "DOT"
-	LeftBrace  TokenType = "LEFT_BRACE"
-	LeftParen  TokenType = "LEFT_PAREN"
-	Minus      TokenType = "MINUS"
-	Plus       TokenType = "PLUS"
-	RightBrace TokenType = "RIGHT_BRACE"
-	RightParen TokenType = "RIGHT_PAREN"
-	Semicolon  TokenType = "SEMICOLON"
-	Slash      TokenType = "SLASH"
-	Star       TokenType = "STAR"
+	Colon        TokenType = "COLON"
+	Comma        TokenType = "COMMA"
+	Dot          TokenType = "DOT"
+	LeftBrace    TokenType = "LEFT_BRACE"
+	LeftBracket  TokenType = "LEFT_BRACKET"
+	LeftParen    TokenType = "LEFT_PAREN"
+	Ampersand    TokenType = "AMPERSAND"
+	Caret        TokenType = "CARET"
+	Minus        TokenType = "MINUS"
+	Percent      TokenType = "PERCENT"
+	Pipe         TokenType = "PIPE"
+	Plus         TokenType = "PLUS"
+	RightBrace   TokenType = "RIGHT_BRACE"
+	RightBracket TokenType = "RIGHT_BRACKET"
+	RightParen   TokenType = "RIGHT_PAREN"
+	Semicolon    TokenType = "SEMICOLON"
+	Slash        TokenType = "SLASH"
+	Star         TokenType = "STAR"
+	Tilde        TokenType = "TILDE"
 
-	// One or two character tokens
-	Bang         TokenType = "BANG"
-	BangEqual    TokenType = "BANG_EQUAL"
-	Equal        TokenType = "EQUAL"
-	EqualEqual   TokenType = "EQUAL_EQUAL"
-	Greater      TokenType = "GREATER"
-	GreaterEqual TokenType = "GREATER_EQUAL"
-	Less         TokenType = "LESS"
-	LessEqual    TokenType = "LESS_EQUAL"
+	// One, two or three character tokens
+	Bang             TokenType = "BANG"
+	BangEqual        TokenType = "BANG_EQUAL"
+	DotDot           TokenType = "DOT_DOT"
+	DotDotDot        TokenType = "DOT_DOT_DOT"
+	Equal            TokenType = "EQUAL"
+	EqualEqual       TokenType = "EQUAL_EQUAL"
+	FatArrow         TokenType = "FAT_ARROW"
+	Greater          TokenType = "GREATER"
+	GreaterEqual     TokenType = "GREATER_EQUAL"
+	GreaterGreater   TokenType = "GREATER_GREATER"
+	Less             TokenType = "LESS"
+	LessEqual        TokenType = "LESS_EQUAL"
+	LessLess         TokenType = "LESS_LESS"
+	MinusEqual       TokenType = "MINUS_EQUAL"
+	MinusMinus       TokenType = "MINUS_MINUS"
+	PercentEqual     TokenType = "PERCENT_EQUAL"
+	PlusEqual        TokenType = "PLUS_EQUAL"
+	PlusPlus         TokenType = "PLUS_PLUS"
+	Question         TokenType = "QUESTION"
+	QuestionDot      TokenType = "QUESTION_DOT"
+	QuestionQuestion TokenType = "QUESTION_QUESTION"
+	SlashEqual       TokenType = "SLASH_EQUAL"
+	StarEqual        TokenType = "STAR_EQUAL"
+	StarStar         TokenType = "STAR_STAR"
+	TildeSlash       TokenType = "TILDE_SLASH"
 
 	// LiteralExprs
 	Identifier TokenType = "IDENTIFIER"
 	Number     TokenType = "NUMBER"
 	String     TokenType = "STRING"
+	// Part of the interpolated string preceding the embedded expression
+	Interpolation TokenType = "INTERPOLATION"
 
 	// Keywords
-	And    TokenType = "AND"
-	Class  TokenType = "CLASS"
-	Else   TokenType = "ELSE"
-	False  TokenType = "FALSE"
-	For    TokenType = "FOR"
-	Func   TokenType = "FUNC"
-	If     TokenType = "IF"
-	Null   TokenType = "NULL"
-	Or     TokenType = "OR"
-	Print  TokenType = "PRINT"
-	Return TokenType = "RETURN"
-	Super  TokenType = "SUPER"
-	This   TokenType = "THIS"
-	True   TokenType = "TRUE"
-	Var    TokenType = "VAR"
-	While  TokenType = "WHILE"
+	And      TokenType = "AND"
+	Break    TokenType = "BREAK"
+	Catch    TokenType = "CATCH"
+	Class    TokenType = "CLASS"
+	Continue TokenType = "CONTINUE"
+	Else     TokenType = "ELSE"
+	Enum     TokenType = "ENUM"
+	False    TokenType = "FALSE"
+	Finally  TokenType = "FINALLY"
+	For      TokenType = "FOR"
+	Func     TokenType = "FUNC"
+	If       TokenType = "IF"
+	In       TokenType = "IN"
+	Is       TokenType = "IS"
+	Match    TokenType = "MATCH"
+	Null     TokenType = "NULL"
+	Or       TokenType = "OR"
+	Print    TokenType = "PRINT"
+	Return   TokenType = "RETURN"
+	Static   TokenType = "STATIC"
+	Super    TokenType = "SUPER"
+	This     TokenType = "THIS"
+	Throw    TokenType = "THROW"
+	Trait    TokenType = "TRAIT"
+	True     TokenType = "TRUE"
+	Try      TokenType = "TRY"
+	Var      TokenType = "VAR"
+	While    TokenType = "WHILE"
+	With     TokenType = "WITH"
 
 	EOF TokenType = "EOF"
 )
@@ -1,43 +1,68 @@
 package main
 
 import (
-	"bytes"
-	"flag"
-	"io/ioutil"
+	"fmt"
+	"os"
+)
 
-	"github.com/adamjedlicka/lang/src/compiler"
-	"github.com/adamjedlicka/lang/src/config"
-	"github.com/adamjedlicka/lang/src/vm"
+// Exit codes follow the conventions of sysexits.h
+const (
+	exitOk      = 0
+	exitUsage   = 64
+	exitCompile = 65
+	exitNoInput = 66
+	exitRuntime = 70
 )
 
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(args []string) int
+}
+
+var commands []command
+
 func init() {
-	flag.Parse()
+	commands = []command{
+		{"run", "run [--engine=tree|vm] file", "Executes the script. Use '-' to read it from stdin.", runCommand},
+		{"repl", "repl", "Starts the interactive prompt.", replCommand},
+		{"disasm", "disasm file", "Prints the bytecode of the script.", disasmCommand},
+		{"check", "check [--engine=tree|vm] file", "Checks the script for compile errors without executing it.", checkCommand},
+		{"version", "version", "Prints the version.", versionCommand},
+	}
 }
 
 func main() {
-	if config.FlagScript != "" {
-		source := loadFile(config.FlagScript)
+	os.Exit(run(os.Args[1:]))
+}
 
-		scanner := compiler.NewScanner(source)
+func run(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return exitUsage
+	}
 
-		parser := compiler.NewParser(scanner)
-		chunk := parser.Parse()
-		if chunk == nil {
-			return
-		}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage()
+		return exitOk
+	}
 
-		vm := vm.NewVM()
-		vm.Interpret(chunk)
-	} else {
-		flag.Usage()
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
 	}
+
+	// Allows executing scripts directly, e.g. through the "#!/usr/bin/env blu" line
+	return runCommand(args)
 }
 
-func loadFile(filename string) []rune {
-	data, err := ioutil.ReadFile(filename)
-	if err != nil {
-		panic(err)
-	}
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: blu <command> [arguments]\n\nCommands:\n")
 
-	return bytes.Runes(data)
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-32s %s\n", cmd.usage, cmd.description)
+	}
 }
@@ -34,6 +34,11 @@ func (c *Chunk) WriteRaw(data uint8, line int) {
 	c.lines = append(c.lines, line)
 }
 
+// SetRaw overwrites the already written byte, it is used to patch the operands of the forward jumps
+func (c *Chunk) SetRaw(offset int, data uint8) {
+	c.code[offset] = data
+}
+
 func (c *Chunk) Get(offset int) OpCode {
 	return OpCode(c.GetRaw(offset))
 }
@@ -5,10 +5,28 @@ type OpCode uint8
 // List of OpCodes
 const (
 	OpConstant OpCode = iota
+	OpNil
+	OpTrue
+	OpFalse
+	OpPop
+	OpGetGlobal
 	OpAdd
 	OpSubtract
 	OpMultiply
 	OpDivide
+	OpModulo
+	OpIntDivide
+	OpPower
 	OpNegate
+	OpBitwiseAnd
+	OpBitwiseOr
+	OpBitwiseXor
+	OpBitwiseNot
+	OpShiftLeft
+	OpShiftRight
+	OpJump
+	OpJumpIfFalse
+	OpJumpIfNotNil
+	OpCall
 	OpReturn
 )
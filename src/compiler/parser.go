@@ -65,6 +65,66 @@ func (p *Parser) number() {
 	p.emitConstant(val.NewNumber(p.previous.lexeme))
 }
 
+func (p *Parser) string() {
+	lexeme := p.previous.lexeme
+
+	// Raw string is taken verbatim, the string can also be the last part of the interpolation starting with '}'.
+	if lexeme[0] == '`' {
+		p.emitConstant(val.NewString(lexeme[1 : len(lexeme)-1]))
+	} else {
+		p.emitConstant(val.NewString(unescape(lexeme[1 : len(lexeme)-1])))
+	}
+}
+
+// interpolation compiles the interpolated string to the concatenation of its parts,
+// the leading string part makes the addition stringify the values of the embedded expressions.
+func (p *Parser) interpolation() {
+	p.interpolationPart()
+
+	for {
+		p.expression()
+		p.emitInstruction(code.OpAdd)
+
+		if p.current.tokenType != TokenInterpolation && p.current.tokenType != TokenString {
+			p.errorAtCurrent("Expect '}' after interpolated expression.")
+			return
+		}
+
+		p.advance()
+
+		if p.previous.tokenType == TokenString {
+			p.string()
+			p.emitInstruction(code.OpAdd)
+			return
+		}
+
+		p.interpolationPart()
+		p.emitInstruction(code.OpAdd)
+	}
+}
+
+// interpolationPart emits the part of the string preceding the embedded expression, without the quote or brace and "${"
+func (p *Parser) interpolationPart() {
+	lexeme := p.previous.lexeme
+
+	p.emitConstant(val.NewString(unescape(lexeme[1 : len(lexeme)-2])))
+}
+
+func (p *Parser) literal() {
+	switch p.previous.tokenType {
+	case TokenFalse:
+		p.emitInstruction(code.OpFalse)
+	case TokenNull:
+		p.emitInstruction(code.OpNil)
+	case TokenTrue:
+		p.emitInstruction(code.OpTrue)
+	}
+}
+
+func (p *Parser) variable() {
+	p.emitBytes(uint8(code.OpGetGlobal), p.makeConstant(val.NewString(p.previous.lexeme)))
+}
+
 func (p *Parser) grouping() {
 	p.expression()
 	p.consume(TokenRightParen, "Expect ')' after expression.")
@@ -80,6 +140,8 @@ func (p *Parser) unary() {
 	switch operatorType {
 	case TokenMinus:
 		p.emitInstruction(code.OpNegate)
+	case TokenTilde:
+		p.emitInstruction(code.OpBitwiseNot)
 	}
 }
 
@@ -87,9 +149,13 @@ func (p *Parser) binary() {
 	// Remember the oprator.
 	operatorType := p.previous.tokenType
 
-	// Compile the right operand.
+	// Compile the right operand, power is right-associative and its right operand can be unary.
 	rule := p.getRule(operatorType)
-	p.parsePrecedence(rule.precedence + 1)
+	if operatorType == TokenStarStar {
+		p.parsePrecedence(PrecedenceUnary)
+	} else {
+		p.parsePrecedence(rule.precedence + 1)
+	}
 
 	switch operatorType {
 	case TokenPlus:
@@ -100,9 +166,81 @@ func (p *Parser) binary() {
 		p.emitInstruction(code.OpMultiply)
 	case TokenSlash:
 		p.emitInstruction(code.OpDivide)
+	case TokenPercent:
+		p.emitInstruction(code.OpModulo)
+	case TokenTildeSlash:
+		p.emitInstruction(code.OpIntDivide)
+	case TokenStarStar:
+		p.emitInstruction(code.OpPower)
+	case TokenAmpersand:
+		p.emitInstruction(code.OpBitwiseAnd)
+	case TokenPipe:
+		p.emitInstruction(code.OpBitwiseOr)
+	case TokenCaret:
+		p.emitInstruction(code.OpBitwiseXor)
+	case TokenLessLess:
+		p.emitInstruction(code.OpShiftLeft)
+	case TokenGreaterGreater:
+		p.emitInstruction(code.OpShiftRight)
 	}
 }
 
+// conditional compiles "condition ? then : else", the condition is already on the stack
+func (p *Parser) conditional() {
+	thenJump := p.emitJump(code.OpJumpIfFalse)
+	p.emitInstruction(code.OpPop)
+	p.expression()
+	p.consume(TokenColon, "Expect ':' after then branch of conditional expression.")
+
+	elseJump := p.emitJump(code.OpJump)
+	p.patchJump(thenJump)
+	p.emitInstruction(code.OpPop)
+
+	// Conditional is right-associative.
+	p.parsePrecedence(PrecedenceConditional)
+	p.patchJump(elseJump)
+}
+
+// coalesce compiles "left ?? right", the right operand is evaluated only when the left one is null
+func (p *Parser) coalesce() {
+	endJump := p.emitJump(code.OpJumpIfNotNil)
+	p.emitInstruction(code.OpPop)
+	p.parsePrecedence(PrecedenceCoalesce + 1)
+	p.patchJump(endJump)
+}
+
+func (p *Parser) call() {
+	argCount := p.argumentList()
+
+	p.emitBytes(uint8(code.OpCall), argCount)
+}
+
+func (p *Parser) argumentList() uint8 {
+	argCount := 0
+
+	if p.current.tokenType != TokenRightParen {
+		for {
+			p.expression()
+
+			if argCount == 255 {
+				p.error("Cannot have more than 255 arguments.")
+			}
+
+			argCount++
+
+			if p.current.tokenType != TokenComma {
+				break
+			}
+
+			p.advance()
+		}
+	}
+
+	p.consume(TokenRightParen, "Expect ')' after arguments.")
+
+	return uint8(argCount)
+}
+
 func (p *Parser) parsePrecedence(precedence Precedence) {
 	p.advance()
 
@@ -157,7 +295,31 @@ func (p *Parser) emitBytes(byte1, byte2 uint8) {
 	p.emitByte(byte2)
 }
 
+// emitJump emits the jump instruction with the placeholder offset and returns the position of the offset
+func (p *Parser) emitJump(instruction code.OpCode) int {
+	p.emitInstruction(instruction)
+	p.emitBytes(0xff, 0xff)
+
+	return p.currentChunk().Len() - 2
+}
+
+// patchJump sets the offset of the jump emitted at the given position to jump right after the last instruction
+func (p *Parser) patchJump(offset int) {
+	jump := p.currentChunk().Len() - offset - 2
+
+	if jump > int(^uint16(0)) {
+		p.error("Too much code to jump over.")
+	}
+
+	p.currentChunk().SetRaw(offset, uint8(jump>>8))
+	p.currentChunk().SetRaw(offset+1, uint8(jump))
+}
+
 func (p *Parser) emitConstant(value val.Value) {
+	p.emitBytes(uint8(code.OpConstant), p.makeConstant(value))
+}
+
+func (p *Parser) makeConstant(value val.Value) uint8 {
 	offset := p.currentChunk().AddConstant(value)
 
 	// Check if offset is greater or equal maximum value of uint8
@@ -165,7 +327,7 @@ func (p *Parser) emitConstant(value val.Value) {
 		p.error("Too many constants in one chunk.")
 	}
 
-	p.emitBytes(uint8(code.OpConstant), offset)
+	return offset
 }
 
 func (p *Parser) currentChunk() *code.Chunk {
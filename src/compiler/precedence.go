@@ -4,15 +4,22 @@ type Precedence uint8
 
 // List of all precedences.
 const (
-	PrecedenceNone       Precedence = iota
-	PrecedenceAssignment            // =
-	PrecedenceOr                    // or
-	PrecedenceAnd                   // and
-	PrecedenceEquality              // == !=
-	PrecedenceComparison            // < > <= >=
-	PrecedenceTerm                  // + -
-	PrecedenceFactor                // * /
-	PrecedenceUnary                 // ! -
-	PrecedenceCall                  // . () []
+	PrecedenceNone        Precedence = iota
+	PrecedenceAssignment             // =
+	PrecedenceConditional            // ?:
+	PrecedenceCoalesce               // ??
+	PrecedenceOr                     // or
+	PrecedenceAnd                    // and
+	PrecedenceEquality               // == !=
+	PrecedenceComparison             // < > <= >=
+	PrecedenceBitwiseOr              // |
+	PrecedenceBitwiseXor             // ^
+	PrecedenceBitwiseAnd             // &
+	PrecedenceShift                  // << >>
+	PrecedenceTerm                   // + -
+	PrecedenceFactor                 // * / % ~/
+	PrecedenceUnary                  // ! - ~
+	PrecedencePower                  // **
+	PrecedenceCall                   // . () []
 	PrecedencePrimary
 )
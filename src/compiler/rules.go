@@ -12,45 +12,64 @@ var rules []ParseRule
 
 func init() {
 	rules = []ParseRule{
-		{(*Parser).grouping, nil, PrecedenceCall},           // TokenLeftParen
-		{nil, nil, PrecedenceNone},                          // TokenRightParen
-		{nil, nil, PrecedenceNone},                          // TokenLeftBrace
-		{nil, nil, PrecedenceNone},                          // TokenRightBrace
-		{nil, nil, PrecedenceNone},                          // TokenComma
-		{nil, nil, PrecedenceCall},                          // TokenDot
-		{(*Parser).unary, (*Parser).binary, PrecedenceTerm}, // TokenMinus
-		{nil, (*Parser).binary, PrecedenceTerm},             // TokenPlus
-		{nil, nil, PrecedenceNone},                          // TokenSemicolon
-		{nil, (*Parser).binary, PrecedenceFactor},           // TokenSlash
-		{nil, (*Parser).binary, PrecedenceFactor},           // TokenStar
-		{nil, nil, PrecedenceNone},                          // TokenBang
-		{nil, nil, PrecedenceEquality},                      // TokenBangEqual
-		{nil, nil, PrecedenceNone},                          // TokenEqual
-		{nil, nil, PrecedenceEquality},                      // TokenEqualEqual
-		{nil, nil, PrecedenceComparison},                    // TokenGreater
-		{nil, nil, PrecedenceComparison},                    // TokenGreaterEqual
-		{nil, nil, PrecedenceComparison},                    // TokenLess
-		{nil, nil, PrecedenceComparison},                    // TokenLessEqual
-		{nil, nil, PrecedenceNone},                          // TokenIdentifier
-		{nil, nil, PrecedenceNone},                          // TokenString
-		{(*Parser).number, nil, PrecedenceNone},             // TokenNumber
-		{nil, nil, PrecedenceAnd},                           // TokenAnd
-		{nil, nil, PrecedenceNone},                          // TokenClass
-		{nil, nil, PrecedenceNone},                          // TokenElse
-		{nil, nil, PrecedenceNone},                          // TokenFalse
-		{nil, nil, PrecedenceNone},                          // TokenFor
-		{nil, nil, PrecedenceNone},                          // TokenFn
-		{nil, nil, PrecedenceNone},                          // TokenIf
-		{nil, nil, PrecedenceNone},                          // TokenNull
-		{nil, nil, PrecedenceOr},                            // TokenOr
-		{nil, nil, PrecedenceNone},                          // TokenPrint
-		{nil, nil, PrecedenceNone},                          // TokenReturn
-		{nil, nil, PrecedenceNone},                          // TokenSuper
-		{nil, nil, PrecedenceNone},                          // TokenThis
-		{nil, nil, PrecedenceNone},                          // TokenTrue
-		{nil, nil, PrecedenceNone},                          // TokenVar
-		{nil, nil, PrecedenceNone},                          // TokenWhile
-		{nil, nil, PrecedenceNone},                          // TokenError
-		{nil, nil, PrecedenceNone},                          // TokenEOF
+		{(*Parser).grouping, (*Parser).call, PrecedenceCall}, // TokenLeftParen
+		{nil, nil, PrecedenceNone},                           // TokenRightParen
+		{nil, nil, PrecedenceNone},                           // TokenLeftBrace
+		{nil, nil, PrecedenceNone},                           // TokenRightBrace
+		{nil, nil, PrecedenceNone},                           // TokenComma
+		{nil, nil, PrecedenceCall},                           // TokenDot
+		{(*Parser).unary, (*Parser).binary, PrecedenceTerm},  // TokenMinus
+		{nil, (*Parser).binary, PrecedenceTerm},              // TokenPlus
+		{nil, nil, PrecedenceNone},                           // TokenSemicolon
+		{nil, (*Parser).binary, PrecedenceFactor},            // TokenSlash
+		{nil, (*Parser).binary, PrecedenceFactor},            // TokenStar
+		{nil, (*Parser).binary, PrecedenceFactor},            // TokenPercent
+		{nil, (*Parser).binary, PrecedenceBitwiseAnd},        // TokenAmpersand
+		{nil, (*Parser).binary, PrecedenceBitwiseOr},         // TokenPipe
+		{nil, (*Parser).binary, PrecedenceBitwiseXor},        // TokenCaret
+		{(*Parser).unary, nil, PrecedenceNone},               // TokenTilde
+		{nil, nil, PrecedenceNone},                           // TokenColon
+		{nil, nil, PrecedenceNone},                           // TokenBang
+		{nil, nil, PrecedenceEquality},                       // TokenBangEqual
+		{nil, nil, PrecedenceNone},                           // TokenEqual
+		{nil, nil, PrecedenceEquality},                       // TokenEqualEqual
+		{nil, nil, PrecedenceComparison},                     // TokenGreater
+		{nil, nil, PrecedenceComparison},                     // TokenGreaterEqual
+		{nil, nil, PrecedenceComparison},                     // TokenLess
+		{nil, nil, PrecedenceComparison},                     // TokenLessEqual
+		{nil, (*Parser).binary, PrecedenceShift},             // TokenLessLess
+		{nil, (*Parser).binary, PrecedenceShift},             // TokenGreaterGreater
+		{nil, (*Parser).binary, PrecedencePower},             // TokenStarStar
+		{nil, (*Parser).binary, PrecedenceFactor},            // TokenTildeSlash
+		{nil, nil, PrecedenceNone},                           // TokenPlusEqual
+		{nil, nil, PrecedenceNone},                           // TokenMinusEqual
+		{nil, nil, PrecedenceNone},                           // TokenStarEqual
+		{nil, nil, PrecedenceNone},                           // TokenSlashEqual
+		{nil, nil, PrecedenceNone},                           // TokenPercentEqual
+		{nil, (*Parser).conditional, PrecedenceConditional},  // TokenQuestion
+		{nil, (*Parser).coalesce, PrecedenceCoalesce},        // TokenQuestionQuestion
+		{nil, nil, PrecedenceNone},                           // TokenQuestionDot
+		{(*Parser).variable, nil, PrecedenceNone},            // TokenIdentifier
+		{(*Parser).string, nil, PrecedenceNone},              // TokenString
+		{(*Parser).number, nil, PrecedenceNone},              // TokenNumber
+		{(*Parser).interpolation, nil, PrecedenceNone},       // TokenInterpolation
+		{nil, nil, PrecedenceAnd},                            // TokenAnd
+		{nil, nil, PrecedenceNone},                           // TokenClass
+		{nil, nil, PrecedenceNone},                           // TokenElse
+		{(*Parser).literal, nil, PrecedenceNone},             // TokenFalse
+		{nil, nil, PrecedenceNone},                           // TokenFor
+		{nil, nil, PrecedenceNone},                           // TokenFn
+		{nil, nil, PrecedenceNone},                           // TokenIf
+		{(*Parser).literal, nil, PrecedenceNone},             // TokenNull
+		{nil, nil, PrecedenceOr},                             // TokenOr
+		{nil, nil, PrecedenceNone},                           // TokenPrint
+		{nil, nil, PrecedenceNone},                           // TokenReturn
+		{nil, nil, PrecedenceNone},                           // TokenSuper
+		{nil, nil, PrecedenceNone},                           // TokenThis
+		{(*Parser).literal, nil, PrecedenceNone},             // TokenTrue
+		{nil, nil, PrecedenceNone},                           // TokenVar
+		{nil, nil, PrecedenceNone},                           // TokenWhile
+		{nil, nil, PrecedenceNone},                           // TokenError
+		{nil, nil, PrecedenceNone},                           // TokenEOF
 	}
 }
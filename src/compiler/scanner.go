@@ -1,7 +1,10 @@
 package compiler
 
 import (
+	"strconv"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 type Scanner struct {
@@ -9,15 +12,22 @@ type Scanner struct {
 	start   int
 	current int
 	line    int
+
+	// Depths of the nested braces in the expressions of the currently open string interpolations
+	interpolations []int
 }
 
 func NewScanner(source []rune) *Scanner {
-	return &Scanner{
+	s := &Scanner{
 		source:  source,
 		start:   0,
 		current: 0,
 		line:    1,
 	}
+
+	s.skipShebang()
+
+	return s
 }
 
 func (s *Scanner) scanToken() Token {
@@ -26,6 +36,11 @@ func (s *Scanner) scanToken() Token {
 	s.start = s.current
 
 	if s.isAtEnd() {
+		if len(s.interpolations) > 0 {
+			s.interpolations = nil
+			return s.errorToken("Unterminated string interpolation.")
+		}
+
 		return s.makeToken(TokenEOF)
 	}
 
@@ -45,23 +60,80 @@ func (s *Scanner) scanToken() Token {
 	case ')':
 		return s.makeToken(TokenRightParen)
 	case '{':
+		if len(s.interpolations) > 0 {
+			s.interpolations[len(s.interpolations)-1]++
+		}
 		return s.makeToken(TokenLeftBrace)
 	case '}':
+		if len(s.interpolations) > 0 {
+			depth := len(s.interpolations) - 1
+			if s.interpolations[depth] == 0 {
+				// Closing brace of the embedded expression continues the interpolated string.
+				s.interpolations = s.interpolations[:depth]
+				return s.string()
+			}
+
+			s.interpolations[depth]--
+		}
 		return s.makeToken(TokenRightBrace)
 	case ';':
 		return s.makeToken(TokenSemicolon)
 	case ',':
 		return s.makeToken(TokenComma)
+	case ':':
+		return s.makeToken(TokenColon)
 	case '.':
+		if s.isDigit(s.peek()) {
+			return s.number()
+		}
 		return s.makeToken(TokenDot)
 	case '-':
+		if s.match('=') {
+			return s.makeToken(TokenMinusEqual)
+		}
 		return s.makeToken(TokenMinus)
 	case '+':
+		if s.match('=') {
+			return s.makeToken(TokenPlusEqual)
+		}
 		return s.makeToken(TokenPlus)
 	case '/':
+		if s.match('=') {
+			return s.makeToken(TokenSlashEqual)
+		}
 		return s.makeToken(TokenSlash)
 	case '*':
+		if s.match('*') {
+			return s.makeToken(TokenStarStar)
+		} else if s.match('=') {
+			return s.makeToken(TokenStarEqual)
+		}
 		return s.makeToken(TokenStar)
+	case '%':
+		if s.match('=') {
+			return s.makeToken(TokenPercentEqual)
+		}
+		return s.makeToken(TokenPercent)
+	case '?':
+		if s.match('?') {
+			return s.makeToken(TokenQuestionQuestion)
+		} else if s.peek() == '.' && !s.isDigit(s.peekNext()) {
+			// Dot followed by a digit starts the number in the conditional like a ?.5 : 1.
+			s.advance()
+			return s.makeToken(TokenQuestionDot)
+		}
+		return s.makeToken(TokenQuestion)
+	case '&':
+		return s.makeToken(TokenAmpersand)
+	case '|':
+		return s.makeToken(TokenPipe)
+	case '^':
+		return s.makeToken(TokenCaret)
+	case '~':
+		if s.match('/') {
+			return s.makeToken(TokenTildeSlash)
+		}
+		return s.makeToken(TokenTilde)
 	case '!':
 		if s.match('=') {
 			return s.makeToken(TokenBangEqual)
@@ -75,15 +147,21 @@ func (s *Scanner) scanToken() Token {
 	case '<':
 		if s.match('=') {
 			return s.makeToken(TokenLessEqual)
+		} else if s.match('<') {
+			return s.makeToken(TokenLessLess)
 		}
 		return s.makeToken(TokenLess)
 	case '>':
 		if s.match('=') {
 			return s.makeToken(TokenGreaterEqual)
+		} else if s.match('>') {
+			return s.makeToken(TokenGreaterGreater)
 		}
 		return s.makeToken(TokenGreater)
 	case '"':
 		return s.string()
+	case '`':
+		return s.rawString()
 	}
 
 	return s.errorToken("Unexpected character.")
@@ -97,8 +175,58 @@ func (s *Scanner) identifier() Token {
 	return s.makeToken(s.identifierType())
 }
 
+// string scans the string up to the closing quote or up to the next "${" which starts the embedded expression.
+// Escape sequences are only validated, the compiler replaces them when it creates the string constant.
 func (s *Scanner) string() Token {
-	for s.peek() != '"' && !s.isAtEnd() {
+	for !s.isAtEnd() {
+		switch s.advance() {
+		case '"':
+			return s.makeToken(TokenString)
+		case '$':
+			if s.match('{') {
+				s.interpolations = append(s.interpolations, 0)
+				return s.makeToken(TokenInterpolation)
+			}
+		case '\\':
+			if !s.escape() {
+				return s.errorToken("Invalid escape sequence.")
+			}
+		case '\n':
+			s.line++
+		}
+	}
+
+	return s.errorToken("Unterminated string.")
+}
+
+// escape skips the escape sequence following the backslash and reports whether it is valid
+func (s *Scanner) escape() bool {
+	if s.isAtEnd() {
+		return false
+	}
+
+	r := s.advance()
+	if _, ok := escapes[r]; ok {
+		return true
+	}
+
+	if r != 'u' || !s.match('{') {
+		return false
+	}
+
+	start := s.current
+	for s.peek() != '}' && !s.isAtEnd() {
+		s.advance()
+	}
+
+	code, err := strconv.ParseUint(string(s.source[start:s.current]), 16, 32)
+
+	return err == nil && s.match('}') && utf8.ValidRune(rune(code))
+}
+
+// rawString scans the string enclosed in backticks, it can span multiple lines and its content is taken verbatim.
+func (s *Scanner) rawString() Token {
+	for s.peek() != '`' && !s.isAtEnd() {
 		if s.peek() == '\n' {
 			s.line++
 		}
@@ -115,12 +243,58 @@ func (s *Scanner) string() Token {
 	return s.makeToken(TokenString)
 }
 
+var escapes = map[rune]rune{
+	'"':  '"',
+	'$':  '$',
+	'0':  0,
+	'\\': '\\',
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+}
+
+// unescape replaces the escape sequences, which were already validated by the scanner, by the characters they represent
+func unescape(text string) string {
+	if !strings.ContainsRune(text, '\\') {
+		return text
+	}
+
+	var value strings.Builder
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '\\' {
+			value.WriteRune(runes[i])
+			continue
+		}
+
+		i++
+		if r, ok := escapes[runes[i]]; ok {
+			value.WriteRune(r)
+			continue
+		}
+
+		// Unicode escape sequence "\u{...}"
+		end := i + 2
+		for runes[end] != '}' {
+			end++
+		}
+
+		code, _ := strconv.ParseUint(string(runes[i+2:end]), 16, 32)
+		value.WriteRune(rune(code))
+		i = end
+	}
+
+	return value.String()
+}
+
 func (s *Scanner) number() Token {
 	for s.isDigit(s.peek()) {
 		s.advance()
 	}
 
-	if s.peek() == '.' && s.isDigit(s.peekNext()) {
+	// Number starting with the dot like .5 has already scanned its fractional part.
+	if s.source[s.start] != '.' && s.peek() == '.' && s.isDigit(s.peekNext()) {
 		s.advance()
 
 		for s.isDigit(s.peek()) {
@@ -186,17 +360,32 @@ func (s *Scanner) match(r rune) bool {
 }
 
 func (s *Scanner) peek() rune {
+	if s.isAtEnd() {
+		return 0
+	}
+
 	return s.source[s.current]
 }
 
 func (s *Scanner) peekNext() rune {
-	if s.isAtEnd() {
+	if s.current+1 >= len(s.source) {
 		return 0
 	}
 
 	return s.source[s.current+1]
 }
 
+// skipShebang skips the "#!" interpreter line at the very beginning of the source.
+func (s *Scanner) skipShebang() {
+	if len(s.source) < 2 || s.source[0] != '#' || s.source[1] != '!' {
+		return
+	}
+
+	for s.peek() != '\n' && !s.isAtEnd() {
+		s.advance()
+	}
+}
+
 func (s *Scanner) skipWhitespace() {
 	if s.isAtEnd() {
 		return
@@ -228,7 +417,7 @@ func (s *Scanner) skipWhitespace() {
 }
 
 func (s *Scanner) isAtEnd() bool {
-	return s.current == len(s.source)-1
+	return s.current >= len(s.source)
 }
 
 func (s *Scanner) isDigit(r rune) bool {
@@ -255,7 +444,7 @@ func (s *Scanner) identifierType() TokenType {
 		if s.current-s.start > 1 {
 			switch s.source[s.start+1] {
 			case 'a':
-				return s.checkKeyword(2, 3, "lse", TokenElse)
+				return s.checkKeyword(2, 3, "lse", TokenFalse)
 			case 'n':
 				return s.checkKeyword(2, 1, "n", TokenFn)
 			case 'o':
@@ -23,6 +23,12 @@ const (
 	TokenSemicolon
 	TokenSlash
 	TokenStar
+	TokenPercent
+	TokenAmpersand
+	TokenPipe
+	TokenCaret
+	TokenTilde
+	TokenColon
 
 	// One or two character tokens.
 	TokenBang
@@ -33,11 +39,24 @@ const (
 	TokenGreaterEqual
 	TokenLess
 	TokenLessEqual
+	TokenLessLess
+	TokenGreaterGreater
+	TokenStarStar
+	TokenTildeSlash
+	TokenPlusEqual
+	TokenMinusEqual
+	TokenStarEqual
+	TokenSlashEqual
+	TokenPercentEqual
+	TokenQuestion
+	TokenQuestionQuestion
+	TokenQuestionDot
 
 	// Literals.
 	TokenIdentifier
 	TokenString
 	TokenNumber
+	TokenInterpolation
 
 	// Keywords.
 	TokenAnd
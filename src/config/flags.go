@@ -2,16 +2,29 @@ package config
 
 import "flag"
 
+// Version is the current version of the language
+const Version = "0.1.0"
+
+// List of available engines
+const (
+	EngineTree = "tree"
+	EngineVM   = "vm"
+)
+
 var (
-	FlagScript string
+	FlagEngine string
 
 	FlagDebug bool
 	FlagStack bool
 )
 
-func init() {
-	flag.StringVar(&FlagScript, "script", "", "Name of the script to be executed.")
+// EngineFlags registers flags selecting the engine used to run the script.
+func EngineFlags(fs *flag.FlagSet) {
+	fs.StringVar(&FlagEngine, "engine", EngineTree, "Engine used to execute the script ("+EngineTree+" or "+EngineVM+").")
+}
 
-	flag.BoolVar(&FlagDebug, "debug", false, "Enables debug messages.")
-	flag.BoolVar(&FlagStack, "stack", false, "Prints out stack before every opcode.")
+// DebugFlags registers flags controlling debug output of the VM.
+func DebugFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&FlagDebug, "debug", false, "Enables debug messages.")
+	fs.BoolVar(&FlagStack, "stack", false, "Prints out stack before every opcode.")
 }
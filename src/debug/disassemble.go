@@ -28,6 +28,16 @@ func DisassembleInstruction(chunk *code.Chunk, offset int) int {
 	switch instruction {
 	case code.OpConstant:
 		return constantInstruction("OpConstant", chunk, offset)
+	case code.OpNil:
+		return simpleInstruction("OpNil", offset)
+	case code.OpTrue:
+		return simpleInstruction("OpTrue", offset)
+	case code.OpFalse:
+		return simpleInstruction("OpFalse", offset)
+	case code.OpPop:
+		return simpleInstruction("OpPop", offset)
+	case code.OpGetGlobal:
+		return constantInstruction("OpGetGlobal", chunk, offset)
 	case code.OpAdd:
 		return simpleInstruction("OpAdd", offset)
 	case code.OpSubtract:
@@ -36,8 +46,34 @@ func DisassembleInstruction(chunk *code.Chunk, offset int) int {
 		return simpleInstruction("OpMultiply", offset)
 	case code.OpDivide:
 		return simpleInstruction("OpDivide", offset)
+	case code.OpModulo:
+		return simpleInstruction("OpModulo", offset)
+	case code.OpIntDivide:
+		return simpleInstruction("OpIntDivide", offset)
+	case code.OpPower:
+		return simpleInstruction("OpPower", offset)
 	case code.OpNegate:
 		return simpleInstruction("OpNegate", offset)
+	case code.OpBitwiseAnd:
+		return simpleInstruction("OpBitwiseAnd", offset)
+	case code.OpBitwiseOr:
+		return simpleInstruction("OpBitwiseOr", offset)
+	case code.OpBitwiseXor:
+		return simpleInstruction("OpBitwiseXor", offset)
+	case code.OpBitwiseNot:
+		return simpleInstruction("OpBitwiseNot", offset)
+	case code.OpShiftLeft:
+		return simpleInstruction("OpShiftLeft", offset)
+	case code.OpShiftRight:
+		return simpleInstruction("OpShiftRight", offset)
+	case code.OpJump:
+		return jumpInstruction("OpJump", chunk, offset)
+	case code.OpJumpIfFalse:
+		return jumpInstruction("OpJumpIfFalse", chunk, offset)
+	case code.OpJumpIfNotNil:
+		return jumpInstruction("OpJumpIfNotNil", chunk, offset)
+	case code.OpCall:
+		return byteInstruction("OpCall", chunk, offset)
 	case code.OpReturn:
 		return simpleInstruction("OpReturn", offset)
 	}
@@ -55,6 +91,20 @@ func constantInstruction(name string, chunk *code.Chunk, offset int) int {
 	return offset + 2
 }
 
+func byteInstruction(name string, chunk *code.Chunk, offset int) int {
+	operand := chunk.GetRaw(offset + 1)
+	fmt.Printf("%-20s %4d\n", name, operand)
+
+	return offset + 2
+}
+
+func jumpInstruction(name string, chunk *code.Chunk, offset int) int {
+	jump := int(chunk.GetRaw(offset+1))<<8 | int(chunk.GetRaw(offset+2))
+	fmt.Printf("%-20s %4d -> %d\n", name, offset, offset+3+jump)
+
+	return offset + 3
+}
+
 func simpleInstruction(name string, offset int) int {
 	fmt.Printf("%s\n", name)
 	return offset + 1
@@ -0,0 +1,15 @@
+package val
+
+type Bool bool
+
+func NewBool(value bool) Bool {
+	return Bool(value)
+}
+
+func (b Bool) String() string {
+	if b {
+		return "true"
+	}
+
+	return "false"
+}
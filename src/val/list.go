@@ -0,0 +1,25 @@
+package val
+
+import (
+	"strings"
+)
+
+type List struct {
+	Elements []Value
+}
+
+func NewList(elements []Value) *List {
+	l := new(List)
+	l.Elements = elements
+
+	return l
+}
+
+func (l *List) String() string {
+	elements := make([]string, len(l.Elements))
+	for i, element := range l.Elements {
+		elements[i] = element.String()
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
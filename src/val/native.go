@@ -0,0 +1,23 @@
+package val
+
+type NativeFn func(args []Value) (Value, error)
+
+type Native struct {
+	Name string
+	// Arity is the number of expected arguments, negative arity means the function checks them itself.
+	Arity int
+	Fn    NativeFn
+}
+
+func NewNative(name string, arity int, fn NativeFn) *Native {
+	n := new(Native)
+	n.Name = name
+	n.Arity = arity
+	n.Fn = fn
+
+	return n
+}
+
+func (n *Native) String() string {
+	return "<native fn " + n.Name + ">"
+}
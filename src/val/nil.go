@@ -0,0 +1,11 @@
+package val
+
+type Nil struct{}
+
+func NewNil() Nil {
+	return Nil{}
+}
+
+func (n Nil) String() string {
+	return "null"
+}
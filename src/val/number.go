@@ -1,11 +1,16 @@
 package val
 
 import (
+	"math"
 	"math/big"
 )
 
 type Number big.Float
 
+func NewNumberFromInt64(i int64) *Number {
+	return (*Number)(new(big.Float).SetInt64(i))
+}
+
 func NewNumber(lexeme string) *Number {
 	f, _, err := new(big.Float).Parse(lexeme, 10)
 	if err != nil {
@@ -31,10 +36,96 @@ func (n *Number) Divide(other Value) Value {
 	return (*Number)((*big.Float)(n).Quo((*big.Float)(n), (*big.Float)(other.(*Number))))
 }
 
+// Modulo returns the remainder of the division truncated towards zero, it has the sign of n.
+// Finite n divided by an infinite number is its own remainder, n must not be infinite.
+func (n *Number) Modulo(other Value) Value {
+	if other.(*Number).IsInf() {
+		return (*Number)(new(big.Float).Copy((*big.Float)(n)))
+	}
+
+	quotient := new(big.Float).SetInt(n.quotient(other.(*Number)))
+	product := new(big.Float).Mul(quotient, (*big.Float)(other.(*Number)))
+
+	return (*Number)(new(big.Float).Sub((*big.Float)(n), product))
+}
+
+// IntDivide returns the quotient of the division truncated towards zero.
+// Infinite n divided by a finite number is infinite, n and other must not be both infinite.
+func (n *Number) IntDivide(other Value) Value {
+	if n.IsInf() {
+		return (*Number)(new(big.Float).Quo((*big.Float)(n), (*big.Float)(other.(*Number))))
+	}
+
+	return (*Number)(new(big.Float).SetInt(n.quotient(other.(*Number))))
+}
+
+func (n *Number) quotient(other *Number) *big.Int {
+	quotient, _ := new(big.Float).Quo((*big.Float)(n), (*big.Float)(other)).Int(nil)
+
+	return quotient
+}
+
+// Power raises n to the power of other. Integral exponents are computed exactly by repeated squaring.
+func (n *Number) Power(other Value) Value {
+	exponent, ok := other.(*Number).Int64()
+	if !ok {
+		base, _ := (*big.Float)(n).Float64()
+		power, _ := (*big.Float)(other.(*Number)).Float64()
+
+		return (*Number)(new(big.Float).SetFloat64(math.Pow(base, power)))
+	}
+
+	negative := exponent < 0
+	if negative {
+		exponent = -exponent
+	}
+
+	result := new(big.Float).SetInt64(1)
+	base := new(big.Float).Copy((*big.Float)(n))
+
+	for ; exponent > 0; exponent >>= 1 {
+		if exponent&1 == 1 {
+			result.Mul(result, base)
+		}
+
+		base.Mul(base, base)
+	}
+
+	if negative {
+		result.Quo(new(big.Float).SetInt64(1), result)
+	}
+
+	return (*Number)(result)
+}
+
 func (n *Number) Negate() Value {
 	return (*Number)((*big.Float)(n).Neg((*big.Float)(n)))
 }
 
+// Int64 returns the number as an integer and whether the conversion was exact.
+func (n *Number) Int64() (int64, bool) {
+	i, accuracy := (*big.Float)(n).Int64()
+
+	return i, accuracy == big.Exact
+}
+
+func (n *Number) Sign() int {
+	return (*big.Float)(n).Sign()
+}
+
+// IsInt reports whether the number is an integer.
+func (n *Number) IsInt() bool {
+	return (*big.Float)(n).IsInt()
+}
+
+func (n *Number) IsInf() bool {
+	return (*big.Float)(n).IsInf()
+}
+
+func (n *Number) IsZero() bool {
+	return (*big.Float)(n).Sign() == 0
+}
+
 func (n *Number) String() string {
 	return (*big.Float)(n).Text('f', -1)
 }
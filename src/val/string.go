@@ -0,0 +1,11 @@
+package val
+
+type String string
+
+func NewString(value string) String {
+	return String(value)
+}
+
+func (s String) String() string {
+	return string(s)
+}
@@ -0,0 +1,41 @@
+package vm
+
+import (
+	"fmt"
+)
+
+type RuntimeError struct {
+	line    int
+	message string
+}
+
+func NewRuntimeError(line int, message string) RuntimeError {
+	return RuntimeError{
+		line:    line,
+		message: message,
+	}
+}
+
+func (e RuntimeError) Error() string {
+	return fmt.Sprintf("[line %d] RuntimeError: %s", e.line, e.message)
+}
+
+// Exiter unwinds the execution of the script when the exit function is called
+type Exiter struct {
+	code int
+}
+
+func NewExiter(code int) Exiter {
+	return Exiter{
+		code: code,
+	}
+}
+
+// Code returns the exit code requested by the script
+func (e Exiter) Code() int {
+	return e.code
+}
+
+func (e Exiter) Error() string {
+	return fmt.Sprintf("EXIT: %d", e.code)
+}
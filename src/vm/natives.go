@@ -0,0 +1,64 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/adamjedlicka/lang/src/val"
+)
+
+func (vm *VM) defineNatives() {
+	vm.defineNative("env", -1, nativeEnv)
+	vm.defineNative("exit", -1, nativeExit)
+
+	vm.globals["args"] = val.NewList(make([]val.Value, 0))
+}
+
+func (vm *VM) defineNative(name string, arity int, fn val.NativeFn) {
+	vm.globals[name] = val.NewNative(name, arity, fn)
+}
+
+func nativeEnv(args []val.Value) (val.Value, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("Expected 1 or 2 arguments but got %d.", len(args))
+	}
+
+	name, ok := args[0].(val.String)
+	if !ok {
+		return nil, errors.New("Name of the environment variable must be a string.")
+	}
+
+	if value, ok := os.LookupEnv(string(name)); ok {
+		return val.NewString(value), nil
+	}
+
+	if len(args) == 2 {
+		return args[1], nil
+	}
+
+	return val.NewNil(), nil
+}
+
+func nativeExit(args []val.Value) (val.Value, error) {
+	if len(args) > 1 {
+		return nil, fmt.Errorf("Expected 0 or 1 arguments but got %d.", len(args))
+	}
+
+	if len(args) == 0 {
+		return nil, NewExiter(0)
+	}
+
+	if code, ok := args[0].(*val.Number); ok {
+		if code, ok := code.Int64(); ok {
+			// Operating systems keep only the lowest byte of the status.
+			if code < 0 || code > 255 {
+				return nil, errors.New("Exit code must be between 0 and 255.")
+			}
+
+			return nil, NewExiter(int(code))
+		}
+	}
+
+	return nil, errors.New("Exit code must be an integer.")
+}
@@ -11,31 +11,55 @@ import (
 )
 
 type VM struct {
-	chunk *code.Chunk
-	ip    int
-	stack []val.Value
+	chunk   *code.Chunk
+	ip      int
+	stack   []val.Value
+	globals map[string]val.Value
 }
 
 func NewVM() *VM {
 	vm := new(VM)
 	vm.ip = 0
 	vm.stack = make([]val.Value, 0)
+	vm.globals = make(map[string]val.Value)
+
+	vm.defineNatives()
 
 	return vm
 }
 
-func (vm *VM) Interpret(chunk *code.Chunk) {
+// SetArgs exposes command-line arguments to the script as the global args list
+func (vm *VM) SetArgs(args []string) {
+	elements := make([]val.Value, len(args))
+	for i, arg := range args {
+		elements[i] = val.NewString(arg)
+	}
+
+	vm.globals["args"] = val.NewList(elements)
+}
+
+func (vm *VM) Interpret(chunk *code.Chunk) error {
 	vm.chunk = chunk
+	vm.ip = 0
 
 	start := time.Now().UnixNano()
-	out := vm.run()
+	out, err := vm.run()
 	end := time.Now().UnixNano()
 
+	if err != nil {
+		return err
+	}
+
 	fmt.Println(out.String())
-	fmt.Printf("time: %dns\n", end-start)
+
+	if config.FlagDebug {
+		fmt.Printf("time: %dns\n", end-start)
+	}
+
+	return nil
 }
 
-func (vm *VM) run() val.Value {
+func (vm *VM) run() (val.Value, error) {
 	for {
 		if config.FlagDebug {
 			if config.FlagStack {
@@ -58,30 +82,159 @@ func (vm *VM) run() val.Value {
 		case code.OpConstant:
 			constant := vm.readConstant()
 			vm.push(constant)
+		case code.OpNil:
+			vm.push(val.NewNil())
+		case code.OpTrue:
+			vm.push(val.NewBool(true))
+		case code.OpFalse:
+			vm.push(val.NewBool(false))
+		case code.OpPop:
+			vm.pop()
+		case code.OpGetGlobal:
+			name := vm.readConstant().String()
+			value, ok := vm.globals[name]
+			if !ok {
+				return nil, vm.runtimeError("Undefined variable '" + name + "'.")
+			}
+			vm.push(value)
 		case code.OpAdd:
 			right := vm.pop()
 			left := vm.pop()
-			vm.push(left.Add(right))
+			if left, ok := left.(val.String); ok {
+				vm.push(left + val.NewString(right.String()))
+				break
+			}
+			l, r, ok := numberOperands(left, right)
+			if !ok {
+				return nil, vm.runtimeError("Operands must be two numbers or two strings.")
+			}
+			vm.push(l.Add(r))
 		case code.OpSubtract:
-			right := vm.pop()
-			left := vm.pop()
-			vm.push(left.Subtract(right))
+			l, r, ok := numberOperands(vm.popOperands())
+			if !ok {
+				return nil, vm.runtimeError("Operands must be numbers.")
+			}
+			vm.push(l.Subtract(r))
 		case code.OpMultiply:
-			right := vm.pop()
-			left := vm.pop()
-			vm.push(left.Multiply(right))
+			l, r, ok := numberOperands(vm.popOperands())
+			if !ok {
+				return nil, vm.runtimeError("Operands must be numbers.")
+			}
+			vm.push(l.Multiply(r))
 		case code.OpDivide:
-			right := vm.pop()
-			left := vm.pop()
-			vm.push(left.Divide(right))
+			l, r, ok := numberOperands(vm.popOperands())
+			if !ok {
+				return nil, vm.runtimeError("Operands must be numbers.")
+			}
+			if l.IsZero() && r.IsZero() {
+				return nil, vm.runtimeError("Cannot divide zero by zero.")
+			}
+			vm.push(l.Divide(r))
+		case code.OpModulo, code.OpIntDivide:
+			l, r, ok := numberOperands(vm.popOperands())
+			if !ok {
+				return nil, vm.runtimeError("Operands must be numbers.")
+			}
+			if r.IsZero() {
+				return nil, vm.runtimeError("Cannot divide by zero.")
+			}
+			// Numbers cannot be NaN, which is the remainder of infinity and the quotient of two infinities
+			if l.IsInf() && (instruction == code.OpModulo || r.IsInf()) {
+				return nil, vm.runtimeError("Result of the operation is not a number.")
+			}
+			if instruction == code.OpModulo {
+				vm.push(l.Modulo(r))
+			} else {
+				vm.push(l.IntDivide(r))
+			}
+		case code.OpPower:
+			l, r, ok := numberOperands(vm.popOperands())
+			if !ok {
+				return nil, vm.runtimeError("Operands must be numbers.")
+			}
+			if l.Sign() < 0 && !r.IsInt() {
+				return nil, vm.runtimeError("Cannot raise a negative number to a fractional power.")
+			}
+			vm.push(l.Power(r))
+		case code.OpBitwiseAnd, code.OpBitwiseOr, code.OpBitwiseXor, code.OpShiftLeft, code.OpShiftRight:
+			l, r, ok := integerOperands(vm.popOperands())
+			if !ok {
+				return nil, vm.runtimeError("Operands must be integers.")
+			}
+			result, err := vm.bitwise(instruction, l, r)
+			if err != nil {
+				return nil, err
+			}
+			vm.push(val.NewNumberFromInt64(result))
+		case code.OpBitwiseNot:
+			operand, ok := vm.pop().(*val.Number)
+			if !ok {
+				return nil, vm.runtimeError("Operand must be an integer.")
+			}
+			i, ok := operand.Int64()
+			if !ok {
+				return nil, vm.runtimeError("Operand must be an integer.")
+			}
+			vm.push(val.NewNumberFromInt64(^i))
 		case code.OpNegate:
-			vm.push(vm.pop().Negate())
+			operand, ok := vm.pop().(*val.Number)
+			if !ok {
+				return nil, vm.runtimeError("Operand must be a number.")
+			}
+			vm.push(operand.Negate())
+		case code.OpJump:
+			offset := vm.readShort()
+			vm.ip += offset
+		case code.OpJumpIfFalse:
+			offset := vm.readShort()
+			if isFalsey(vm.peek()) {
+				vm.ip += offset
+			}
+		case code.OpJumpIfNotNil:
+			offset := vm.readShort()
+			if _, ok := vm.peek().(val.Nil); !ok {
+				vm.ip += offset
+			}
+		case code.OpCall:
+			argCount := int(vm.readInstruction())
+			err := vm.call(argCount)
+			if err != nil {
+				return nil, err
+			}
 		case code.OpReturn:
-			return vm.pop()
+			return vm.pop(), nil
 		}
 	}
 }
 
+func (vm *VM) call(argCount int) error {
+	callee := vm.stack[len(vm.stack)-1-argCount]
+
+	native, ok := callee.(*val.Native)
+	if !ok {
+		return vm.runtimeError("Can only call functions.")
+	}
+
+	if native.Arity >= 0 && native.Arity != argCount {
+		return vm.runtimeError(fmt.Sprintf("Expected %d arguments but got %d.", native.Arity, argCount))
+	}
+
+	args := make([]val.Value, argCount)
+	copy(args, vm.stack[len(vm.stack)-argCount:])
+
+	result, err := native.Fn(args)
+	if _, ok := err.(Exiter); ok {
+		return err
+	} else if err != nil {
+		return vm.runtimeError(err.Error())
+	}
+
+	vm.stack = vm.stack[:len(vm.stack)-1-argCount]
+	vm.push(result)
+
+	return nil
+}
+
 func (vm *VM) push(value val.Value) {
 	vm.stack = append(vm.stack, value)
 }
@@ -94,6 +247,17 @@ func (vm *VM) pop() val.Value {
 	return value
 }
 
+func (vm *VM) peek() val.Value {
+	return vm.stack[len(vm.stack)-1]
+}
+
+func (vm *VM) popOperands() (val.Value, val.Value) {
+	right := vm.pop()
+	left := vm.pop()
+
+	return left, right
+}
+
 func (vm *VM) readInstruction() code.OpCode {
 	instruction := vm.chunk.Get(vm.ip)
 
@@ -102,6 +266,69 @@ func (vm *VM) readInstruction() code.OpCode {
 	return instruction
 }
 
+func (vm *VM) readShort() int {
+	high := int(vm.readInstruction())
+	low := int(vm.readInstruction())
+
+	return high<<8 | low
+}
+
 func (vm *VM) readConstant() val.Value {
 	return vm.chunk.GetConstant(uint8(vm.readInstruction()))
 }
+
+func (vm *VM) runtimeError(message string) error {
+	return NewRuntimeError(vm.chunk.GetLine(vm.ip-1), message)
+}
+
+func (vm *VM) bitwise(instruction code.OpCode, l, r int64) (int64, error) {
+	switch instruction {
+	case code.OpBitwiseAnd:
+		return l & r, nil
+	case code.OpBitwiseOr:
+		return l | r, nil
+	case code.OpBitwiseXor:
+		return l ^ r, nil
+	}
+
+	if r < 0 {
+		return 0, vm.runtimeError("Shift count cannot be negative.")
+	}
+
+	if instruction == code.OpShiftLeft {
+		return l << uint64(r), nil
+	}
+
+	return l >> uint64(r), nil
+}
+
+// isFalsey reports whether the value is null or false, all other values are truthy
+func isFalsey(value val.Value) bool {
+	switch value := value.(type) {
+	case val.Nil:
+		return true
+	case val.Bool:
+		return !bool(value)
+	}
+
+	return false
+}
+
+func integerOperands(left, right val.Value) (int64, int64, bool) {
+	l, r, ok := numberOperands(left, right)
+	if !ok {
+		return 0, 0, false
+	}
+
+	li, okLeft := l.Int64()
+	ri, okRight := r.Int64()
+
+	return li, ri, okLeft && okRight
+}
+
+func numberOperands(left, right val.Value) (*val.Number, *val.Number, bool) {
+	l, okLeft := left.(*val.Number)
+	r, okRight := right.(*val.Number)
+
+	return l, r, okLeft && okRight
+}